@@ -8,9 +8,11 @@ import (
 
 	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
 	"github.com/miti99/store-scraper-bot-go/internal/api/google"
+	"github.com/miti99/store-scraper-bot-go/internal/audit"
 	"github.com/miti99/store-scraper-bot-go/internal/bot"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
 	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/review"
 	"github.com/miti99/store-scraper-bot-go/internal/scheduler"
 	"go.uber.org/zap"
 )
@@ -38,19 +40,47 @@ func main() {
 	groupRepo := repository.NewGroupRepository()
 	appleAppRepo := repository.NewAppleAppRepository()
 	googleAppRepo := repository.NewGoogleAppRepository()
+	auditRepo := repository.NewAuditRepository()
+	snapshotRepo := repository.NewSnapshotRepository()
+	reviewRepo := repository.NewReviewRepository()
+	changeRepo := repository.NewChangeRepository()
+	rankingRepo := repository.NewRankingRepository()
+
+	// Merge admins persisted at runtime with ADMIN_IDS
+	admins, err := adminRepo.GetAllAdmins()
+	if err != nil {
+		cfg.Logger.Fatal("Failed to load admins", zap.Error(err))
+	}
+	for _, admin := range admins {
+		cfg.AddAdminIDs(admin.UserID)
+	}
+	cfg.Logger.Info("Loaded admins", zap.Int("count", len(cfg.GetAdminIDs())))
+
+	// Fold apps added once per country into a single entry with storefronts
+	if merged, err := groupRepo.MergeDuplicateApps(); err != nil {
+		cfg.Logger.Error("Failed to merge duplicate apps", zap.Error(err))
+	} else if merged > 0 {
+		cfg.Logger.Info("Merged duplicate apps", zap.Int("groups", merged))
+	}
 
 	// Initialize scrapers
-	appleScraper := apple.NewAppleScraper(appleAppRepo, cfg)
-	googleScraper := google.NewGoogleScraper(googleAppRepo, cfg)
+	appleScraper := apple.NewAppleScraper(appleAppRepo, snapshotRepo, changeRepo, cfg)
+	googleScraper := google.NewGoogleScraper(googleAppRepo, snapshotRepo, changeRepo, cfg)
+
+	// Initialize review collection
+	reviewCollector := review.NewCollector(reviewRepo, appleScraper, googleScraper, cfg)
+
+	// Initialize audit log
+	auditor := audit.NewAuditor(auditRepo, cfg)
 
 	// Initialize bot
-	telegramBot, err := bot.NewBot(cfg, adminRepo, groupRepo, appleScraper, googleScraper)
+	telegramBot, err := bot.NewBot(cfg, adminRepo, groupRepo, appleScraper, googleScraper, snapshotRepo, rankingRepo, reviewCollector, auditor)
 	if err != nil {
 		cfg.Logger.Fatal("Failed to initialize bot", zap.Error(err))
 	}
 
 	// Initialize and start scheduler
-	sched := scheduler.NewScheduler(cfg, telegramBot, adminRepo, groupRepo, appleScraper, googleScraper)
+	sched := scheduler.NewScheduler(cfg, telegramBot, adminRepo, groupRepo, snapshotRepo, changeRepo, rankingRepo, appleScraper, googleScraper, reviewCollector)
 	if err := sched.Start(); err != nil {
 		cfg.Logger.Fatal("Failed to start scheduler", zap.Error(err))
 	}
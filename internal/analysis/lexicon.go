@@ -0,0 +1,63 @@
+package analysis
+
+// Sentiment lexicons for English and Vietnamese review text. Vietnamese
+// words are often two syllables, so phrases are matched as bigrams first.
+var positiveWords = setOf(
+	// English
+	"good", "great", "excellent", "awesome", "amazing", "love", "loved", "loving", "like", "nice",
+	"best", "perfect", "fantastic", "wonderful", "useful", "helpful", "easy", "fast", "smooth",
+	"fun", "enjoy", "enjoyed", "recommend", "recommended", "beautiful", "cool", "stable",
+	"convenient", "happy", "thanks", "thank", "worth", "reliable", "intuitive", "friendly",
+	"addictive", "satisfied", "impressive", "quick", "improved", "brilliant", "glad",
+	// Vietnamese
+	"tốt", "hay", "thích", "đẹp", "nhanh", "mượt", "tuyệt", "ổn", "xịn", "yêu",
+	"tuyệt vời", "dễ dùng", "hữu ích", "tiện lợi", "hài lòng", "cảm ơn", "ưng ý", "ổn định",
+	"rất tốt", "chất lượng", "đáng tiền", "thú vị",
+)
+
+var negativeWords = setOf(
+	// English
+	"bad", "terrible", "awful", "horrible", "worst", "hate", "hated", "poor", "useless", "slow",
+	"bug", "bugs", "buggy", "crash", "crashes", "crashed", "crashing", "broken", "error", "errors",
+	"freeze", "freezes", "frozen", "lag", "laggy", "annoying", "scam", "fraud", "waste", "disappointed",
+	"disappointing", "problem", "problems", "issue", "issues", "fail", "fails", "failed", "stuck",
+	"refund", "uninstall", "uninstalled", "ads", "spam", "expensive", "greedy", "unusable",
+	"confusing", "ugly", "worse", "glitch", "glitches", "cheat", "cheating", "lost", "cannot",
+	"can't", "doesn't", "won't", "fix", "rubbish", "garbage", "trash", "boring", "rip",
+	// Vietnamese
+	"tệ", "lỗi", "chán", "dở", "lag", "đơ", "chậm", "kém", "ghét", "tồi",
+	"lừa đảo", "quảng cáo", "không được", "thất vọng", "mất tiền", "hoàn tiền", "gỡ cài",
+	"bị lỗi", "văng ra", "treo máy", "rất tệ", "quá tệ", "tốn tiền", "không vào",
+)
+
+// negations flip the polarity of the next sentiment word.
+var negations = setOf(
+	"not", "no", "never", "don't", "didn't", "isn't", "wasn't", "aren't", "nothing", "hardly",
+	"không", "chẳng", "chả", "chưa", "đừng",
+)
+
+// stopwords are left out of topic summaries.
+var stopwords = setOf(
+	// English
+	"the", "a", "an", "and", "or", "but", "is", "are", "was", "were", "be", "been", "it", "its",
+	"this", "that", "these", "those", "to", "of", "in", "on", "for", "with", "at", "by", "from",
+	"as", "so", "if", "i", "me", "my", "we", "our", "you", "your", "they", "them", "he", "she",
+	"his", "her", "have", "has", "had", "do", "does", "did", "just", "very", "really", "too",
+	"all", "can", "will", "would", "could", "should", "there", "what", "when", "which", "who",
+	"app", "game", "one", "get", "got", "even", "also", "more", "much", "some", "any", "than",
+	"then", "now", "only", "out", "up", "about", "please", "still", "time", "use", "using",
+	"it's", "i'm", "i've", "im", "not", "no", "don't", "after", "because", "again", "every",
+	// Vietnamese
+	"và", "là", "của", "có", "cho", "được", "thì", "mà", "này", "đó", "các", "những", "một",
+	"với", "để", "khi", "cũng", "rất", "quá", "nhưng", "tôi", "mình", "em", "anh", "bạn", "nó",
+	"đã", "đang", "sẽ", "vẫn", "lại", "ra", "vào", "trong", "trên", "nên", "thì", "lắm", "gì",
+	"app", "game", "không", "chưa", "nữa", "rồi", "nhé", "ạ", "ko", "k",
+)
+
+func setOf(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
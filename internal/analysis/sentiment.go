@@ -0,0 +1,72 @@
+// Package analysis summarizes review text offline with word lists, without
+// external services.
+package analysis
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenize lowercases text and splits it into words. Apostrophes are kept so
+// contractions such as "don't" stay one word.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’'
+	})
+}
+
+// Sentiment scores text from -1 (negative) to 1 (positive), 0 when no
+// sentiment word is found. A negation flips the next sentiment word.
+func Sentiment(text string) float64 {
+	tokens := Tokenize(strings.ReplaceAll(text, "’", "'"))
+
+	positive, negative := 0, 0
+	negated := false
+	for i := 0; i < len(tokens); i++ {
+		polarity, width := polarityAt(tokens, i)
+		if polarity == 0 {
+			if negations[tokens[i]] {
+				negated = true
+			}
+			continue
+		}
+
+		if negated {
+			polarity = -polarity
+			negated = false
+		}
+		if polarity > 0 {
+			positive++
+		} else {
+			negative++
+		}
+		i += width - 1
+	}
+
+	if positive+negative == 0 {
+		return 0
+	}
+	return float64(positive-negative) / float64(positive+negative)
+}
+
+// polarityAt returns the polarity of the phrase starting at tokens[i] and
+// how many tokens it spans, preferring two word phrases.
+func polarityAt(tokens []string, i int) (int, int) {
+	if i+1 < len(tokens) {
+		bigram := tokens[i] + " " + tokens[i+1]
+		if positiveWords[bigram] {
+			return 1, 2
+		}
+		if negativeWords[bigram] {
+			return -1, 2
+		}
+	}
+
+	switch {
+	case positiveWords[tokens[i]]:
+		return 1, 1
+	case negativeWords[tokens[i]]:
+		return -1, 1
+	}
+	return 0, 1
+}
@@ -0,0 +1,165 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+)
+
+const (
+	// negativeThreshold is the sentiment below which a review is negative.
+	negativeThreshold = -0.2
+	positiveThreshold = 0.2
+
+	minTermLength = 2
+)
+
+// Stats describes the sentiment of a set of reviews.
+type Stats struct {
+	Reviews   int
+	Sentiment float64 // Average, -1 to 1
+	Positive  int
+	Neutral   int
+	Negative  int
+}
+
+// Topic counts the negative reviews mentioning a term in two periods.
+type Topic struct {
+	Term     string
+	Current  int
+	Previous int
+}
+
+type Summary struct {
+	Current  Stats
+	Previous Stats
+	Topics   []Topic // Most mentioned in current negative reviews first
+}
+
+// IsNegative classifies a review using its text, falling back to the star
+// rating when the text carries no sentiment words.
+func IsNegative(review model.Review) bool {
+	sentiment := Sentiment(review.Title + " " + review.Text)
+	if sentiment == 0 {
+		return review.Score <= 2
+	}
+	return sentiment < negativeThreshold
+}
+
+// Summarize compares the reviews of the current period with the previous
+// one and returns at most maxTopics topics.
+func Summarize(current, previous []model.Review, maxTopics int) Summary {
+	summary := Summary{
+		Current:  stats(current),
+		Previous: stats(previous),
+	}
+
+	currentTerms := negativeTerms(current)
+	previousTerms := negativeTerms(previous)
+
+	terms := make([]string, 0, len(currentTerms))
+	for term, count := range currentTerms {
+		// A single mention is noise
+		if count > 1 {
+			terms = append(terms, term)
+		}
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		a, b := currentTerms[terms[i]], currentTerms[terms[j]]
+		if a != b {
+			return a > b
+		}
+		// Prefer phrases, e.g. "quảng cáo" over "cáo"
+		if len(strings.Fields(terms[i])) != len(strings.Fields(terms[j])) {
+			return len(strings.Fields(terms[i])) > len(strings.Fields(terms[j]))
+		}
+		return terms[i] < terms[j]
+	})
+
+	var selected []string
+	for _, term := range terms {
+		if len(summary.Topics) >= maxTopics {
+			break
+		}
+		if overlaps(term, selected) {
+			continue
+		}
+		selected = append(selected, term)
+		summary.Topics = append(summary.Topics, Topic{
+			Term:     term,
+			Current:  currentTerms[term],
+			Previous: previousTerms[term],
+		})
+	}
+
+	return summary
+}
+
+func stats(reviews []model.Review) Stats {
+	s := Stats{Reviews: len(reviews)}
+	if len(reviews) == 0 {
+		return s
+	}
+
+	total := 0.0
+	for _, r := range reviews {
+		sentiment := Sentiment(r.Title + " " + r.Text)
+		total += sentiment
+		switch {
+		case IsNegative(r):
+			s.Negative++
+		case sentiment > positiveThreshold:
+			s.Positive++
+		default:
+			s.Neutral++
+		}
+	}
+	s.Sentiment = total / float64(len(reviews))
+	return s
+}
+
+// negativeTerms counts in how many negative reviews each word and two word
+// phrase appears.
+func negativeTerms(reviews []model.Review) map[string]int {
+	counts := make(map[string]int)
+	for _, r := range reviews {
+		if !IsNegative(r) {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		tokens := Tokenize(r.Title + " " + r.Text)
+		for i, token := range tokens {
+			if isTopicWord(token) {
+				seen[token] = true
+				if i+1 < len(tokens) && isTopicWord(tokens[i+1]) {
+					seen[token+" "+tokens[i+1]] = true
+				}
+			}
+		}
+		for term := range seen {
+			counts[term]++
+		}
+	}
+	return counts
+}
+
+func isTopicWord(token string) bool {
+	return len([]rune(token)) >= minTermLength && !stopwords[token]
+}
+
+// overlaps reports whether term shares a word with a selected term, so the
+// summary does not list "ads" next to "many ads".
+func overlaps(term string, selected []string) bool {
+	for _, s := range selected {
+		for _, word := range strings.Fields(term) {
+			for _, other := range strings.Fields(s) {
+				if word == other {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
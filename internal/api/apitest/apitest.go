@@ -0,0 +1,57 @@
+// Package apitest holds helpers shared by the store scraper tests.
+package apitest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+)
+
+// redirectTransport sends every request to the test server, keeping the path.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// NewClient starts a test server with the handler and returns a client that
+// sends every request to it. The server is closed when the test ends.
+func NewClient(t *testing.T, handler http.HandlerFunc) *http.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &http.Client{Transport: redirectTransport{target}}
+}
+
+// CheckTopChart checks the entries parsed from the testdata/top_chart.json
+// fixture, which lists three apps in both stores.
+func CheckTopChart(t *testing.T, chart model.ChartInfo, entries []model.ChartEntry) {
+	t.Helper()
+
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	if entries[2] != (model.ChartEntry{Position: 3, AppID: "com.other.third", Title: "Third: Puzzles & More"}) {
+		t.Errorf("entries[2] = %+v", entries[2])
+	}
+
+	ranking := model.NewChartRanking(chart, entries)
+	for appID, want := range map[string]int{"com.example.first": 1, "com.example.second": 2, "com.missing": 0} {
+		if got := ranking.Position(appID); got != want {
+			t.Errorf("Position(%s) = %d, want %d", appID, got, want)
+		}
+	}
+}
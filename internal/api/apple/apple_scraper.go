@@ -8,13 +8,27 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/miti99/store-scraper-bot-go/internal/api"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
 	"github.com/miti99/store-scraper-bot-go/internal/model"
 	"github.com/miti99/store-scraper-bot-go/internal/repository"
 	"go.uber.org/zap"
 )
 
-const appleAPIURL = "https://store-scraper.vercel.app/apple/app"
+const (
+	appleAPIURL          = "https://store-scraper.vercel.app/apple/app"
+	appleSearchAPIURL    = "https://store-scraper.vercel.app/apple/search"
+	appleDeveloperAPIURL = "https://store-scraper.vercel.app/apple/developer"
+	appleReviewsAPIURL   = "https://store-scraper.vercel.app/apple/reviews"
+	appleListAPIURL      = "https://store-scraper.vercel.app/apple/list"
+)
+
+// appleCollections maps chart collections to the store's feed names.
+var appleCollections = map[string]string{
+	model.ChartTopFree:     "topfreeapplications",
+	model.ChartTopPaid:     "toppaidapplications",
+	model.ChartTopGrossing: "topgrossingapplications",
+}
 
 type AppleAppRequest struct {
 	ID      *int64  `json:"id,omitempty"`
@@ -23,25 +37,59 @@ type AppleAppRequest struct {
 	Ratings bool    `json:"ratings"`
 }
 
+type AppleSearchRequest struct {
+	Term    string `json:"term"`
+	Country string `json:"country"`
+	Num     int    `json:"num"`
+}
+
+type AppleDeveloperRequest struct {
+	DevID   string `json:"devId"`
+	Country string `json:"country"`
+}
+
+type AppleReviewsRequest struct {
+	AppID   string `json:"appId"`
+	Country string `json:"country"`
+	Sort    string `json:"sort"`
+	Page    int    `json:"page"`
+}
+
+type AppleListRequest struct {
+	Collection string `json:"collection"`
+	Category   string `json:"category,omitempty"`
+	Country    string `json:"country"`
+	Num        int    `json:"num"`
+}
+
 type AppleScraper struct {
-	httpClient *http.Client
-	appRepo    *repository.AppleAppRepository
-	logger     *zap.Logger
+	httpClient   *http.Client
+	appRepo      *repository.AppleAppRepository
+	snapshotRepo *repository.SnapshotRepository
+	changeRepo   *repository.ChangeRepository
+	logger       *zap.Logger
 }
 
-func NewAppleScraper(appRepo *repository.AppleAppRepository, cfg *config.Config) *AppleScraper {
+func NewAppleScraper(
+	appRepo *repository.AppleAppRepository,
+	snapshotRepo *repository.SnapshotRepository,
+	changeRepo *repository.ChangeRepository,
+	cfg *config.Config,
+) *AppleScraper {
 	return &AppleScraper{
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		appRepo: appRepo,
-		logger:  cfg.Logger,
+		appRepo:      appRepo,
+		snapshotRepo: snapshotRepo,
+		changeRepo:   changeRepo,
+		logger:       cfg.Logger,
 	}
 }
 
 func (s *AppleScraper) GetApp(appID, country string) (*model.AppleAppResponse, error) {
 	// Check cache first
-	cachedApp, err := s.appRepo.GetCached(appID)
+	cachedApp, err := s.appRepo.GetCached(appID, country)
 	if err != nil {
 		s.logger.Error("Failed to get cached apple app", zap.Error(err), zap.String("appId", appID))
 	}
@@ -61,11 +109,26 @@ func (s *AppleScraper) GetApp(appID, country string) (*model.AppleAppResponse, e
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	appleApp := model.NewAppleApp(appID, *response)
+	appleApp := model.NewAppleApp(appID, country, *response)
 	if err := s.appRepo.Save(ctx, appleApp); err != nil {
 		s.logger.Error("Failed to save apple app to cache", zap.Error(err), zap.String("appId", appID))
 	}
 
+	// Record history and listing changes since the previous scrape
+	snapshot := model.NewAppleSnapshot(appID, country, *response)
+	previous, err := s.snapshotRepo.GetLatest(ctx, model.StoreApple, appID, country)
+	if err != nil {
+		s.logger.Error("Failed to get previous apple app snapshot", zap.Error(err), zap.String("appId", appID))
+	}
+	if err := s.snapshotRepo.Save(ctx, snapshot); err != nil {
+		s.logger.Error("Failed to save apple app snapshot", zap.Error(err), zap.String("appId", appID))
+	}
+	if changes := snapshot.DiffListing(previous); len(changes) > 0 {
+		if err := s.changeRepo.Insert(ctx, model.NewMetadataChange(snapshot, changes)); err != nil {
+			s.logger.Error("Failed to save apple app metadata change", zap.Error(err), zap.String("appId", appID))
+		}
+	}
+
 	return response, nil
 }
 
@@ -76,40 +139,127 @@ func (s *AppleScraper) fetchFromAPI(appID, country string) (*model.AppleAppRespo
 		Ratings: true,
 	}
 
+	var response model.AppleAppResponse
+	if err := s.post(appleAPIURL, request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (s *AppleScraper) GetAppUpdated(appID, country string) (string, error) {
+	app, err := s.GetApp(appID, country)
+	if err != nil {
+		return "", err
+	}
+	return app.Updated, nil
+}
+
+// Search returns the top store results for the term.
+func (s *AppleScraper) Search(term, country string, num int) ([]model.AppleAppResponse, error) {
+	s.logger.Info("Searching apple apps", zap.String("term", term), zap.String("country", country))
+
+	request := AppleSearchRequest{
+		Term:    term,
+		Country: country,
+		Num:     num,
+	}
+
+	var response []model.AppleAppResponse
+	if err := s.post(appleSearchAPIURL, request, &response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetDeveloperApps lists all apps published by the developer.
+func (s *AppleScraper) GetDeveloperApps(devID, country string) ([]model.AppleAppResponse, error) {
+	s.logger.Info("Fetching apple developer apps", zap.String("devId", devID), zap.String("country", country))
+
+	request := AppleDeveloperRequest{
+		DevID:   devID,
+		Country: country,
+	}
+
+	var response []model.AppleAppResponse
+	if err := s.post(appleDeveloperAPIURL, request, &response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetReviews returns the most recent reviews of the app. Reviews are not
+// cached, callers store them.
+func (s *AppleScraper) GetReviews(appID, country string) ([]model.AppleReviewResponse, error) {
+	s.logger.Info("Fetching apple reviews", zap.String("appId", appID), zap.String("country", country))
+
+	request := AppleReviewsRequest{
+		AppID:   appID,
+		Country: country,
+		Sort:    "recent",
+		Page:    1,
+	}
+
+	var response []model.AppleReviewResponse
+	if err := s.post(appleReviewsAPIURL, request, &response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetTopChart returns the first num apps of the chart, ranked in order.
+func (s *AppleScraper) GetTopChart(chart model.ChartInfo, num int) ([]model.ChartEntry, error) {
+	s.logger.Info("Fetching apple top chart", zap.String("chart", chart.Key()))
+
+	collection, ok := appleCollections[chart.Collection]
+	if !ok {
+		return nil, fmt.Errorf("unknown chart collection: %s", chart.Collection)
+	}
+
+	request := AppleListRequest{
+		Collection: collection,
+		Category:   chart.Category,
+		Country:    chart.Country,
+		Num:        num,
+	}
+
+	var response []model.AppleAppResponse
+	if err := s.post(appleListAPIURL, request, &response); err != nil {
+		return nil, err
+	}
+
+	return model.NewAppleChartEntries(response), nil
+}
+
+func (s *AppleScraper) post(url string, request, response any) error {
 	requestBody, err := json.Marshal(request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", appleAPIURL, bytes.NewBuffer(requestBody))
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(requestBody))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return api.NewRequestError(err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status code: %d", resp.StatusCode)
+		return api.NewStatusError(resp)
 	}
 
-	var response model.AppleAppResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return api.NewDecodeError(err)
 	}
 
-	return &response, nil
-}
-
-func (s *AppleScraper) GetAppUpdated(appID, country string) (string, error) {
-	app, err := s.GetApp(appID, country)
-	if err != nil {
-		return "", err
-	}
-	return app.Updated, nil
+	return nil
 }
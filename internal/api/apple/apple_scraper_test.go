@@ -0,0 +1,55 @@
+package apple
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/miti99/store-scraper-bot-go/internal/api/apitest"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"go.uber.org/zap"
+)
+
+func TestGetTopChart(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/top_chart.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var request AppleListRequest
+	client := apitest.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apple/list" {
+			t.Errorf("path = %s, want /apple/list", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Write(fixture)
+	})
+	scraper := &AppleScraper{
+		httpClient: client,
+		logger:     zap.NewNop(),
+	}
+
+	chart := model.ChartInfo{Store: model.StoreApple, Collection: model.ChartTopFree, Category: "6014", Country: "us"}
+	entries, err := scraper.GetTopChart(chart, 3)
+	if err != nil {
+		t.Fatalf("GetTopChart: %v", err)
+	}
+
+	want := AppleListRequest{Collection: "topfreeapplications", Category: "6014", Country: "us", Num: 3}
+	if request != want {
+		t.Errorf("request = %+v, want %+v", request, want)
+	}
+
+	apitest.CheckTopChart(t, chart, entries)
+}
+
+func TestGetTopChartUnknownCollection(t *testing.T) {
+	scraper := &AppleScraper{httpClient: http.DefaultClient, logger: zap.NewNop()}
+	chart := model.ChartInfo{Store: model.StoreApple, Collection: "topnew", Country: "us"}
+	if _, err := scraper.GetTopChart(chart, 10); err == nil {
+		t.Error("expected an error for an unknown collection")
+	}
+}
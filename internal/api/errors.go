@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Error kinds returned by the scrapers. Use errors.Is to check them.
+var (
+	// ErrNotFound means the store does not list the app, e.g. it was removed
+	// or is not available in the requested country.
+	ErrNotFound            = errors.New("app not found in store")
+	ErrRateLimited         = errors.New("rate limited by store")
+	ErrUpstreamUnavailable = errors.New("store service unavailable")
+	ErrBadResponse         = errors.New("bad response from store")
+	ErrTimeout             = errors.New("store request timed out")
+)
+
+// Error is a scraper failure. It matches its Kind with errors.Is and keeps
+// the underlying cause and HTTP details for logging.
+type Error struct {
+	Kind       error
+	StatusCode int
+	RetryAfter time.Duration // Set for rate limited responses when known
+	Err        error
+}
+
+func (e *Error) Error() string {
+	msg := e.Kind.Error()
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(" (status code: %d)", e.StatusCode)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// NewRequestError classifies a failure to get any response at all.
+func NewRequestError(err error) *Error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &Error{Kind: ErrTimeout, Err: err}
+	}
+	return &Error{Kind: ErrUpstreamUnavailable, Err: err}
+}
+
+// NewStatusError classifies a non-200 response. The upstream reports a
+// missing app as 404, or as 500 with an "App not found" message depending on
+// the store library, so the body of a 500 is inspected too. Rate limits and
+// other server errors are checked first, as their bodies may echo the URL or
+// mention "not found" for unrelated reasons.
+func NewStatusError(resp *http.Response) *Error {
+	e := &Error{StatusCode: resp.StatusCode}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		e.Kind = ErrRateLimited
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			e.RetryAfter = time.Duration(seconds) * time.Second
+		}
+	case resp.StatusCode == http.StatusNotFound:
+		e.Kind = ErrNotFound
+	case resp.StatusCode == http.StatusInternalServerError && isAppNotFoundBody(resp.Body):
+		e.Kind = ErrNotFound
+	case resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusGatewayTimeout:
+		e.Kind = ErrTimeout
+	case resp.StatusCode >= 500:
+		e.Kind = ErrUpstreamUnavailable
+	default:
+		e.Kind = ErrBadResponse
+	}
+
+	return e
+}
+
+// isAppNotFoundBody reports whether a 500 body is the store library's missing
+// app error, e.g. {"message":"App not found (404)"}.
+func isAppNotFoundBody(body io.Reader) bool {
+	data, _ := io.ReadAll(io.LimitReader(body, 4096))
+	return strings.Contains(strings.ToLower(string(data)), "app not found")
+}
+
+// NewDecodeError wraps a response body that could not be parsed.
+func NewDecodeError(err error) *Error {
+	return &Error{Kind: ErrBadResponse, Err: err}
+}
+
+// Label returns a short error class for tables and logs.
+func Label(err error) string {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return "not found"
+	case errors.Is(err, ErrRateLimited):
+		return "rate limited"
+	case errors.Is(err, ErrUpstreamUnavailable):
+		return "unavailable"
+	case errors.Is(err, ErrBadResponse):
+		return "bad response"
+	case errors.Is(err, ErrTimeout):
+		return "timeout"
+	default:
+		return "error"
+	}
+}
+
+// Reason returns a user facing explanation of a scraper error.
+func Reason(err error) string {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return "app not found in store, it may be removed or unavailable in this country"
+	case errors.Is(err, ErrRateLimited):
+		return "store rate limit reached, please try again later"
+	case errors.Is(err, ErrUpstreamUnavailable):
+		return "store service is unavailable, please try again later"
+	case errors.Is(err, ErrBadResponse):
+		return "store returned an unexpected response"
+	case errors.Is(err, ErrTimeout):
+		return "store request timed out, please try again later"
+	default:
+		return err.Error()
+	}
+}
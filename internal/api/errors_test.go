@@ -0,0 +1,54 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewStatusError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"not found", http.StatusNotFound, "", ErrNotFound},
+		{"library not found", http.StatusInternalServerError, `{"message":"App not found (404)"}`, ErrNotFound},
+		{"server error", http.StatusInternalServerError, `{"message":"socket hang up"}`, ErrUpstreamUnavailable},
+		{"rate limited mentioning not found", http.StatusTooManyRequests, "app not found", ErrRateLimited},
+		{"bad gateway mentioning not found", http.StatusBadGateway, "upstream not found", ErrUpstreamUnavailable},
+		{"timeout", http.StatusGatewayTimeout, "", ErrTimeout},
+		{"bad request mentioning not found", http.StatusBadRequest, "country not found", ErrBadResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.status,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}
+
+			err := NewStatusError(resp)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("NewStatusError(%d, %q) = %v, want %v", tt.status, tt.body, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStatusErrorRetryAfter(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"30"}},
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+
+	if err := NewStatusError(resp); err.RetryAfter != 30*time.Second {
+		t.Errorf("RetryAfter = %v, want 30s", err.RetryAfter)
+	}
+}
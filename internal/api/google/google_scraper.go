@@ -8,38 +8,93 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/miti99/store-scraper-bot-go/internal/api"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
 	"github.com/miti99/store-scraper-bot-go/internal/model"
 	"github.com/miti99/store-scraper-bot-go/internal/repository"
 	"go.uber.org/zap"
 )
 
-const googleAPIURL = "https://store-scraper.vercel.app/google/app"
+const (
+	googleAPIURL          = "https://store-scraper.vercel.app/google/app"
+	googleSearchAPIURL    = "https://store-scraper.vercel.app/google/search"
+	googleDeveloperAPIURL = "https://store-scraper.vercel.app/google/developer"
+	googleReviewsAPIURL   = "https://store-scraper.vercel.app/google/reviews"
+	googleListAPIURL      = "https://store-scraper.vercel.app/google/list"
+)
+
+// maxDeveloperApps caps the developer listing; Google paginates otherwise.
+const maxDeveloperApps = 200
+
+// numReviews is how many recent reviews are fetched per request.
+const numReviews = 100
+
+// googleCollections maps chart collections to the store's cluster names.
+var googleCollections = map[string]string{
+	model.ChartTopFree:     "TOP_FREE",
+	model.ChartTopPaid:     "TOP_PAID",
+	model.ChartTopGrossing: "GROSSING",
+}
 
 type GoogleAppRequest struct {
 	AppID   string `json:"appId"`
 	Country string `json:"country"`
 }
 
+type GoogleSearchRequest struct {
+	Term    string `json:"term"`
+	Country string `json:"country"`
+	Num     int    `json:"num"`
+}
+
+type GoogleDeveloperRequest struct {
+	DevID   string `json:"devId"`
+	Country string `json:"country"`
+	Num     int    `json:"num"`
+}
+
+type GoogleReviewsRequest struct {
+	AppID   string `json:"appId"`
+	Country string `json:"country"`
+	Sort    string `json:"sort"`
+	Num     int    `json:"num"`
+}
+
+type GoogleListRequest struct {
+	Collection string `json:"collection"`
+	Category   string `json:"category,omitempty"`
+	Country    string `json:"country"`
+	Num        int    `json:"num"`
+}
+
 type GoogleScraper struct {
-	httpClient *http.Client
-	appRepo    *repository.GoogleAppRepository
-	logger     *zap.Logger
+	httpClient   *http.Client
+	appRepo      *repository.GoogleAppRepository
+	snapshotRepo *repository.SnapshotRepository
+	changeRepo   *repository.ChangeRepository
+	logger       *zap.Logger
 }
 
-func NewGoogleScraper(appRepo *repository.GoogleAppRepository, cfg *config.Config) *GoogleScraper {
+func NewGoogleScraper(
+	appRepo *repository.GoogleAppRepository,
+	snapshotRepo *repository.SnapshotRepository,
+	changeRepo *repository.ChangeRepository,
+	cfg *config.Config,
+) *GoogleScraper {
 	return &GoogleScraper{
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		appRepo: appRepo,
-		logger:  cfg.Logger,
+		appRepo:      appRepo,
+		snapshotRepo: snapshotRepo,
+		changeRepo:   changeRepo,
+		logger:       cfg.Logger,
 	}
 }
 
 func (s *GoogleScraper) GetApp(appID, country string) (*model.GoogleAppResponse, error) {
 	// Check cache first
-	cachedApp, err := s.appRepo.GetCached(appID)
+	cachedApp, err := s.appRepo.GetCached(appID, country)
 	if err != nil {
 		s.logger.Error("Failed to get cached google app", zap.Error(err), zap.String("appId", appID))
 	}
@@ -59,11 +114,26 @@ func (s *GoogleScraper) GetApp(appID, country string) (*model.GoogleAppResponse,
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	googleApp := model.NewGoogleApp(appID, *response)
+	googleApp := model.NewGoogleApp(appID, country, *response)
 	if err := s.appRepo.Save(ctx, googleApp); err != nil {
 		s.logger.Error("Failed to save google app to cache", zap.Error(err), zap.String("appId", appID))
 	}
 
+	// Record history and listing changes since the previous scrape
+	snapshot := model.NewGoogleSnapshot(appID, country, *response)
+	previous, err := s.snapshotRepo.GetLatest(ctx, model.StoreGoogle, appID, country)
+	if err != nil {
+		s.logger.Error("Failed to get previous google app snapshot", zap.Error(err), zap.String("appId", appID))
+	}
+	if err := s.snapshotRepo.Save(ctx, snapshot); err != nil {
+		s.logger.Error("Failed to save google app snapshot", zap.Error(err), zap.String("appId", appID))
+	}
+	if changes := snapshot.DiffListing(previous); len(changes) > 0 {
+		if err := s.changeRepo.Insert(ctx, model.NewMetadataChange(snapshot, changes)); err != nil {
+			s.logger.Error("Failed to save google app metadata change", zap.Error(err), zap.String("appId", appID))
+		}
+	}
+
 	return response, nil
 }
 
@@ -73,40 +143,128 @@ func (s *GoogleScraper) fetchFromAPI(appID, country string) (*model.GoogleAppRes
 		Country: country,
 	}
 
+	var response model.GoogleAppResponse
+	if err := s.post(googleAPIURL, request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (s *GoogleScraper) GetLastUpdate(appID, country string) (int64, error) {
+	app, err := s.GetApp(appID, country)
+	if err != nil {
+		return 0, err
+	}
+	return app.Updated, nil
+}
+
+// Search returns the top store results for the term.
+func (s *GoogleScraper) Search(term, country string, num int) ([]model.GoogleAppResponse, error) {
+	s.logger.Info("Searching google apps", zap.String("term", term), zap.String("country", country))
+
+	request := GoogleSearchRequest{
+		Term:    term,
+		Country: country,
+		Num:     num,
+	}
+
+	var response []model.GoogleAppResponse
+	if err := s.post(googleSearchAPIURL, request, &response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetDeveloperApps lists all apps published by the developer.
+func (s *GoogleScraper) GetDeveloperApps(devID, country string) ([]model.GoogleAppResponse, error) {
+	s.logger.Info("Fetching google developer apps", zap.String("devId", devID), zap.String("country", country))
+
+	request := GoogleDeveloperRequest{
+		DevID:   devID,
+		Country: country,
+		Num:     maxDeveloperApps,
+	}
+
+	var response []model.GoogleAppResponse
+	if err := s.post(googleDeveloperAPIURL, request, &response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetReviews returns the most recent reviews of the app. Reviews are not
+// cached, callers store them.
+func (s *GoogleScraper) GetReviews(appID, country string) ([]model.GoogleReviewResponse, error) {
+	s.logger.Info("Fetching google reviews", zap.String("appId", appID), zap.String("country", country))
+
+	request := GoogleReviewsRequest{
+		AppID:   appID,
+		Country: country,
+		Sort:    "newest",
+		Num:     numReviews,
+	}
+
+	var response model.GoogleReviewsResponse
+	if err := s.post(googleReviewsAPIURL, request, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// GetTopChart returns the first num apps of the chart, ranked in order.
+func (s *GoogleScraper) GetTopChart(chart model.ChartInfo, num int) ([]model.ChartEntry, error) {
+	s.logger.Info("Fetching google top chart", zap.String("chart", chart.Key()))
+
+	collection, ok := googleCollections[chart.Collection]
+	if !ok {
+		return nil, fmt.Errorf("unknown chart collection: %s", chart.Collection)
+	}
+
+	request := GoogleListRequest{
+		Collection: collection,
+		Category:   chart.Category,
+		Country:    chart.Country,
+		Num:        num,
+	}
+
+	var response []model.GoogleAppResponse
+	if err := s.post(googleListAPIURL, request, &response); err != nil {
+		return nil, err
+	}
+
+	return model.NewGoogleChartEntries(response), nil
+}
+
+func (s *GoogleScraper) post(url string, request, response any) error {
 	requestBody, err := json.Marshal(request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", googleAPIURL, bytes.NewBuffer(requestBody))
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(requestBody))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return api.NewRequestError(err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status code: %d", resp.StatusCode)
+		return api.NewStatusError(resp)
 	}
 
-	var response model.GoogleAppResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return api.NewDecodeError(err)
 	}
 
-	return &response, nil
-}
-
-func (s *GoogleScraper) GetLastUpdate(appID, country string) (int64, error) {
-	app, err := s.GetApp(appID, country)
-	if err != nil {
-		return 0, err
-	}
-	return app.Updated, nil
+	return nil
 }
@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// maxResultLength caps the stored command reply; reports can be several KB.
+const maxResultLength = 500
+
+type Auditor struct {
+	auditRepo *repository.AuditRepository
+	logger    *zap.Logger
+}
+
+func NewAuditor(auditRepo *repository.AuditRepository, cfg *config.Config) *Auditor {
+	return &Auditor{
+		auditRepo: auditRepo,
+		logger:    cfg.Logger,
+	}
+}
+
+// Record stores a command execution. Failures are logged and never block
+// the command itself.
+func (a *Auditor) Record(userID, chatID int64, command, arguments, result string) {
+	result = util.TruncateString(result, maxResultLength)
+
+	a.logger.Info("Audit",
+		zap.Int64("userId", userID),
+		zap.Int64("chatId", chatID),
+		zap.String("command", command),
+		zap.String("arguments", arguments))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	entry := model.NewAuditEntry(userID, chatID, command, arguments, result)
+	if err := a.auditRepo.Insert(ctx, entry); err != nil {
+		a.logger.Error("Failed to record audit entry", zap.Error(err), zap.String("command", command))
+	}
+}
+
+func (a *Auditor) GetRecent(chatID int64, limit int) ([]model.AuditEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return a.auditRepo.GetRecentByChat(ctx, chatID, limit)
+}
@@ -1,26 +1,50 @@
 package bot
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
 	"github.com/miti99/store-scraper-bot-go/internal/api/google"
+	"github.com/miti99/store-scraper-bot-go/internal/audit"
 	"github.com/miti99/store-scraper-bot-go/internal/bot/command"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
 	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/review"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
 	"go.uber.org/zap"
 )
 
+const (
+	// maxMessageLength keeps messages under Telegram's 4096 character limit,
+	// with room for the closing code fence.
+	maxMessageLength = 4000
+	// maxMessageChunks is the most messages a text is split into before it
+	// is sent as a document.
+	maxMessageChunks = 5
+)
+
 type Bot struct {
-	api           *tgbotapi.BotAPI
-	cfg           *config.Config
-	adminRepo     *repository.AdminRepository
-	groupRepo     *repository.GroupRepository
-	appleScraper  *apple.AppleScraper
-	googleScraper *google.GoogleScraper
-	commands      map[string]command.Command
-	logger        *zap.Logger
+	api             *tgbotapi.BotAPI
+	cfg             *config.Config
+	adminRepo       *repository.AdminRepository
+	groupRepo       *repository.GroupRepository
+	appleScraper    *apple.AppleScraper
+	googleScraper   *google.GoogleScraper
+	snapshotRepo    *repository.SnapshotRepository
+	rankingRepo     *repository.RankingRepository
+	reviewCollector *review.Collector
+	auditor         *audit.Auditor
+	registry        *command.Registry
+	logger          *zap.Logger
+
+	// menuMu serializes the rate limited command menu calls
+	menuMu          sync.Mutex
+	menuLastRequest time.Time
 }
 
 func NewBot(
@@ -29,6 +53,10 @@ func NewBot(
 	groupRepo *repository.GroupRepository,
 	appleScraper *apple.AppleScraper,
 	googleScraper *google.GoogleScraper,
+	snapshotRepo *repository.SnapshotRepository,
+	rankingRepo *repository.RankingRepository,
+	reviewCollector *review.Collector,
+	auditor *audit.Auditor,
 ) (*Bot, error) {
 	bot, err := tgbotapi.NewBotAPI(cfg.TelegramBotToken)
 	if err != nil {
@@ -40,14 +68,18 @@ func NewBot(
 	cfg.Logger.Info("Authorized on account", zap.String("username", bot.Self.UserName))
 
 	b := &Bot{
-		api:           bot,
-		cfg:           cfg,
-		adminRepo:     adminRepo,
-		groupRepo:     groupRepo,
-		appleScraper:  appleScraper,
-		googleScraper: googleScraper,
-		commands:      make(map[string]command.Command),
-		logger:        cfg.Logger,
+		api:             bot,
+		cfg:             cfg,
+		adminRepo:       adminRepo,
+		groupRepo:       groupRepo,
+		appleScraper:    appleScraper,
+		googleScraper:   googleScraper,
+		snapshotRepo:    snapshotRepo,
+		rankingRepo:     rankingRepo,
+		reviewCollector: reviewCollector,
+		auditor:         auditor,
+		registry:        command.NewRegistry(cfg, adminRepo),
+		logger:          cfg.Logger,
 	}
 
 	b.registerCommands()
@@ -55,28 +87,82 @@ func NewBot(
 }
 
 func (b *Bot) registerCommands() {
-	b.commands["addgroup"] = command.NewAddGroupCommand(b.cfg, b.adminRepo, b.groupRepo)
-	b.commands["deletegroup"] = command.NewDeleteGroupCommand(b.cfg, b.adminRepo, b.groupRepo)
-	b.commands["listgroup"] = command.NewListGroupCommand(b.cfg, b.adminRepo)
-	b.commands["addapple"] = command.NewAddAppleAppCommand(b.cfg, b.adminRepo, b.groupRepo, b.appleScraper)
-	b.commands["deleteapple"] = command.NewDeleteAppleAppCommand(b.cfg, b.adminRepo, b.groupRepo)
-	b.commands["addgoogle"] = command.NewAddGoogleAppCommand(b.cfg, b.adminRepo, b.groupRepo, b.googleScraper)
-	b.commands["deletegoogle"] = command.NewDeleteGoogleAppCommand(b.cfg, b.adminRepo, b.groupRepo)
-	b.commands["listapp"] = command.NewListAppCommand(b.cfg, b.adminRepo, b.groupRepo)
-	b.commands["checkapp"] = command.NewCheckAppCommand(b.cfg, b.adminRepo, b.groupRepo, b.appleScraper, b.googleScraper)
-	b.commands["checkappscores"] = command.NewCheckAppScoresCommand(b.cfg, b.adminRepo, b.groupRepo, b.appleScraper, b.googleScraper)
-	b.commands["rawapple"] = command.NewRawAppleAppCommand(b.cfg, b.appleScraper)
-	b.commands["rawgoogle"] = command.NewRawGoogleAppCommand(b.cfg, b.googleScraper)
-	b.commands["info"] = command.NewInfoCommand(b.cfg)
+	b.registry.Use(b.auditMiddleware)
+	b.registry.Register(
+		command.NewAddGroupCommand(b.cfg, b.adminRepo, b.groupRepo, b),
+		command.NewDeleteGroupCommand(b.cfg, b.adminRepo, b.groupRepo, b),
+		command.NewListGroupCommand(b.cfg, b.adminRepo),
+		command.NewAddAppleAppCommand(b.cfg, b.adminRepo, b.groupRepo, b.appleScraper),
+		command.NewDeleteAppleAppCommand(b.cfg, b.adminRepo, b.groupRepo),
+		command.NewAddGoogleAppCommand(b.cfg, b.adminRepo, b.groupRepo, b.googleScraper),
+		command.NewDeleteGoogleAppCommand(b.cfg, b.adminRepo, b.groupRepo),
+		command.NewListAppCommand(b.cfg, b.adminRepo, b.groupRepo, b.appleScraper, b.googleScraper),
+		command.NewCheckAppCommand(b.cfg, b.adminRepo, b.groupRepo, b.appleScraper, b.googleScraper),
+		command.NewCheckAppScoresCommand(b.cfg, b.adminRepo, b.groupRepo, b.snapshotRepo, b.appleScraper, b.googleScraper),
+		command.NewCompareCommand(b.cfg, b.groupRepo, b.appleScraper, b.googleScraper),
+		command.NewPricesCommand(b.cfg, b.groupRepo, b.snapshotRepo, b.appleScraper, b.googleScraper),
+		command.NewRankCommand(b.cfg, b.groupRepo, b.rankingRepo),
+		command.NewAddChartCommand(b.cfg, b.groupRepo),
+		command.NewDeleteChartCommand(b.cfg, b.groupRepo),
+		command.NewListChartsCommand(b.cfg, b.groupRepo),
+		command.NewDigestCommand(b.cfg, b.groupRepo),
+		command.NewHistogramCommand(b.cfg, b.snapshotRepo, b.appleScraper, b.googleScraper),
+		command.NewHistoryCommand(b.cfg, b.snapshotRepo),
+		command.NewChangelogCommand(b.cfg, b.snapshotRepo, b.appleScraper, b.googleScraper),
+		command.NewReviewsCommand(b.cfg, b.reviewCollector),
+		command.NewAddRuleCommand(b.cfg, b.groupRepo),
+		command.NewDeleteRuleCommand(b.cfg, b.groupRepo),
+		command.NewListRulesCommand(b.cfg, b.groupRepo),
+		command.NewLabelCommand(b.cfg, b.groupRepo),
+		command.NewTagCommand(b.cfg, b.groupRepo),
+		command.NewUntagCommand(b.cfg, b.groupRepo),
+		command.NewBenchmarkCommand(b.cfg, b.groupRepo, b.snapshotRepo, b.appleScraper, b.googleScraper),
+		command.NewSearchAppCommand(b.cfg, b.groupRepo, b.appleScraper, b.googleScraper),
+		command.NewAddDeveloperCommand(b.cfg, b.groupRepo, b.appleScraper, b.googleScraper),
+		command.NewDeleteDeveloperCommand(b.cfg, b.groupRepo),
+		command.NewRawAppleAppCommand(b.cfg, b.appleScraper),
+		command.NewRawGoogleAppCommand(b.cfg, b.googleScraper),
+		command.NewAddAdminCommand(b.cfg, b.adminRepo, b),
+		command.NewRemoveAdminCommand(b.cfg, b.adminRepo, b),
+		command.NewListAdminsCommand(b.cfg, b.adminRepo),
+		command.NewAuditCommand(b.cfg, b.auditor),
+		command.NewHelpCommand(b.cfg, b.registry),
+		command.NewInfoCommand(b.cfg, b.registry),
+	)
+}
+
+// auditMiddleware records every invocation of a mutating command, including
+// rejected ones.
+func (b *Bot) auditMiddleware(meta command.Metadata, next command.Handler) command.Handler {
+	if !meta.Mutating {
+		return next
+	}
+	return func(req *command.Request) command.Reply {
+		reply := next(req)
+		b.auditor.Record(req.UserID(), req.ChatID(), meta.Name, req.Message.CommandArguments(), reply.Text)
+		return reply
+	}
 }
 
 func (b *Bot) Start() {
+	// Publishing is rate limited and can take a while, so poll meanwhile
+	go func() {
+		if err := b.publishCommands(); err != nil {
+			b.logger.Error("Failed to publish bot commands", zap.Error(err))
+		}
+	}()
+
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
 	updates := b.api.GetUpdatesChan(u)
 
 	for update := range updates {
+		if update.CallbackQuery != nil {
+			go b.handleCallback(update.CallbackQuery)
+			continue
+		}
+
 		if update.Message == nil {
 			continue
 		}
@@ -91,9 +177,8 @@ func (b *Bot) Start() {
 
 func (b *Bot) handleCommand(message *tgbotapi.Message) {
 	commandName := message.Command()
-	cmd, exists := b.commands[commandName]
 
-	if !exists {
+	if _, exists := b.registry.Get(commandName); !exists {
 		b.logger.Debug("Unknown command", zap.String("command", commandName))
 		return
 	}
@@ -103,34 +188,147 @@ func (b *Bot) handleCommand(message *tgbotapi.Message) {
 		zap.Int64("userId", message.From.ID),
 		zap.Int64("chatId", message.Chat.ID))
 
-	response := cmd.Execute(message)
-	if response != "" {
-		msg := tgbotapi.NewMessage(message.Chat.ID, response)
+	reply, _ := b.registry.Handle(commandName, message)
+	if reply.Text != "" {
+		if err := b.sendText(message.Chat.ID, reply.Text, false, reply.Keyboard); err != nil {
+			b.logger.Error("Failed to send message", zap.Error(err))
+		}
+	}
+
+	if reply.Photo != nil {
+		if err := b.SendPhoto(message.Chat.ID, reply.Photo, ""); err != nil {
+			b.logger.Error("Failed to send photo", zap.Error(err))
+		}
+	}
+}
+
+func (b *Bot) handleCallback(query *tgbotapi.CallbackQuery) {
+	reply, exists := b.registry.HandleCallback(query)
+	if !exists {
+		b.logger.Debug("Unknown callback", zap.String("data", query.Data))
+	} else {
+		b.logger.Info("Handling callback",
+			zap.String("data", query.Data),
+			zap.Int64("userId", query.From.ID),
+			zap.Int64("chatId", query.Message.Chat.ID))
+	}
+
+	// Always answer so the client stops showing the loading state
+	if _, err := b.api.Request(tgbotapi.NewCallback(query.ID, reply.Notice)); err != nil {
+		b.logger.Error("Failed to answer callback", zap.Error(err))
+	}
+
+	if !exists {
+		return
+	}
+
+	chatID := query.Message.Chat.ID
+	if reply.AuditArgs != "" {
+		name, _, _ := strings.Cut(query.Data, ":")
+		b.auditor.Record(query.From.ID, chatID, name, reply.AuditArgs, reply.Text)
+	}
+
+	if reply.Text != "" {
+		var edit tgbotapi.EditMessageTextConfig
+		if reply.Keyboard != nil {
+			edit = tgbotapi.NewEditMessageTextAndMarkup(chatID, query.Message.MessageID, reply.Text, *reply.Keyboard)
+		} else {
+			edit = tgbotapi.NewEditMessageText(chatID, query.Message.MessageID, reply.Text)
+		}
+		edit.ParseMode = "Markdown"
+		edit.DisableWebPagePreview = true
+
+		_, err := b.api.Send(edit)
+		if isMarkdownError(err) {
+			b.logger.Warn("Markdown rejected, editing as plain text", zap.Error(err))
+			edit.ParseMode = ""
+			_, err = b.api.Send(edit)
+		}
+		if err != nil {
+			b.logger.Error("Failed to edit message", zap.Error(err))
+		}
+	}
+
+	if reply.Message != "" {
+		if err := b.SendMessage(chatID, reply.Message); err != nil {
+			b.logger.Error("Failed to send message", zap.Error(err))
+		}
+	}
+}
+
+func (b *Bot) SendMessage(chatID int64, text string) error {
+	return b.sendText(chatID, text, false, nil)
+}
+
+func (b *Bot) SendMessageSilent(chatID int64, text string) error {
+	return b.sendText(chatID, text, true, nil)
+}
+
+// sendText sends Markdown text, split into several messages when it exceeds
+// Telegram's limit. Text that would take more than maxMessageChunks messages
+// is sent as a document instead. The keyboard goes with the last message, or
+// with the document.
+func (b *Bot) sendText(chatID int64, text string, silent bool, keyboard *tgbotapi.InlineKeyboardMarkup) error {
+	chunks := util.SplitMessage(text, maxMessageLength)
+	if len(chunks) > maxMessageChunks {
+		return b.sendDocument(chatID, text, silent, keyboard)
+	}
+
+	for i, chunk := range chunks {
+		msg := tgbotapi.NewMessage(chatID, chunk)
 		msg.ParseMode = "Markdown"
 		msg.DisableWebPagePreview = true
+		msg.DisableNotification = silent
+		if keyboard != nil && i == len(chunks)-1 {
+			msg.ReplyMarkup = keyboard
+		}
 
 		if _, err := b.api.Send(msg); err != nil {
-			b.logger.Error("Failed to send message", zap.Error(err))
+			if !isMarkdownError(err) {
+				return err
+			}
+			// Send the text as is rather than not at all
+			b.logger.Warn("Markdown rejected, sending as plain text", zap.Error(err))
+			msg.ParseMode = ""
+			if _, err := b.api.Send(msg); err != nil {
+				return err
+			}
 		}
 	}
+	return nil
 }
 
-func (b *Bot) SendMessage(chatID int64, text string) error {
-	msg := tgbotapi.NewMessage(chatID, text)
-	msg.ParseMode = "Markdown"
-	msg.DisableWebPagePreview = true
-	msg.DisableNotification = false
+// isMarkdownError reports whether Telegram rejected a message's Markdown.
+func isMarkdownError(err error) bool {
+	var apiErr *tgbotapi.Error
+	return errors.As(err, &apiErr) && strings.Contains(apiErr.Message, "can't parse entities")
+}
 
-	_, err := b.api.Send(msg)
+// sendDocument sends text as a plain text file, with the first line, usually
+// the report title, as caption. The caption is plain text, since truncating
+// the title can cut its Markdown in half.
+func (b *Bot) sendDocument(chatID int64, text string, silent bool, keyboard *tgbotapi.InlineKeyboardMarkup) error {
+	title, _, _ := strings.Cut(text, "\n")
+	title = strings.Trim(title, "*_")
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{Name: "output.txt", Bytes: []byte(text)})
+	doc.Caption = util.TruncateString(title, 200) + "\nToo long for a message, sent as a file."
+	doc.DisableNotification = silent
+	if keyboard != nil {
+		doc.ReplyMarkup = keyboard
+	}
+
+	_, err := b.api.Send(doc)
 	return err
 }
 
-func (b *Bot) SendMessageSilent(chatID int64, text string) error {
-	msg := tgbotapi.NewMessage(chatID, text)
-	msg.ParseMode = "Markdown"
-	msg.DisableWebPagePreview = true
-	msg.DisableNotification = true
+// SendPhoto sends a PNG image, e.g. a chart, with an optional Markdown caption.
+func (b *Bot) SendPhoto(chatID int64, image []byte, caption string) error {
+	photo := tgbotapi.NewPhoto(chatID, tgbotapi.FileBytes{Name: "chart.png", Bytes: image})
+	if caption != "" {
+		photo.Caption = caption
+		photo.ParseMode = "Markdown"
+	}
 
-	_, err := b.api.Send(msg)
+	_, err := b.api.Send(photo)
 	return err
 }
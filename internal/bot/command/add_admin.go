@@ -0,0 +1,74 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"go.uber.org/zap"
+)
+
+type AddAdminCommand struct {
+	BaseCommand
+	adminRepo *repository.AdminRepository
+	menus     MenuPublisher
+}
+
+func NewAddAdminCommand(cfg *config.Config, adminRepo *repository.AdminRepository, menus MenuPublisher) *AddAdminCommand {
+	return &AddAdminCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		adminRepo:   adminRepo,
+		menus:       menus,
+	}
+}
+
+func (c *AddAdminCommand) Metadata() Metadata {
+	return Metadata{
+		Name:        "addadmin",
+		Description: "Add admin, or reply to a user's message",
+		Args:        []Arg{userIDArg},
+		Example:     "/addadmin 123456789",
+		Permission:  PermissionCreator,
+		Mutating:    true,
+	}
+}
+
+func (c *AddAdminCommand) Execute(req *Request) Reply {
+	userID, ok := targetUserID(req)
+	if !ok {
+		return Reply{Text: c.Metadata().UsageText()}
+	}
+
+	if c.cfg.IsAdmin(userID) {
+		return Reply{Text: fmt.Sprintf("User %d is already an admin.", userID)}
+	}
+
+	if err := c.adminRepo.AddAdmin(userID, req.UserID()); err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to add admin: %v", err)}
+	}
+	c.cfg.AddAdminIDs(userID)
+	c.menus.PublishAdminMenu(userID)
+
+	c.cfg.Logger.Info("Admin added",
+		zap.Int64("userId", userID),
+		zap.Int64("addedBy", req.UserID()))
+
+	return Reply{Text: fmt.Sprintf("User %d has been added as admin.", userID)}
+}
+
+var userIDArg = Arg{Name: "userId", Type: ArgInt}
+
+// targetUserID reads the user ID from the arguments, falling back to the
+// author of the replied-to message.
+func targetUserID(req *Request) (int64, bool) {
+	if req.Args.Has("userId") {
+		return req.Args.Int("userId"), true
+	}
+
+	reply := req.Message.ReplyToMessage
+	if reply != nil && reply.From != nil {
+		return reply.From.ID, true
+	}
+
+	return 0, false
+}
@@ -2,12 +2,12 @@ package command
 
 import (
 	"fmt"
-	"strings"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/miti99/store-scraper-bot-go/internal/api"
 	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
 	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
 )
 
 type AddAppleAppCommand struct {
@@ -31,40 +31,34 @@ func NewAddAppleAppCommand(
 	}
 }
 
-func (c *AddAppleAppCommand) Execute(message *tgbotapi.Message) string {
-	if !c.requireAdmin(message) {
-		return "You are not authorized to use this command."
-	}
-
-	groupID := message.Chat.ID
-	hasGroup, err := c.adminRepo.HasGroup(groupID)
-	if err != nil {
-		return fmt.Sprintf("Failed to check group: %v", err)
-	}
-	if !hasGroup {
-		return "This group is not registered. Please use /addgroup first."
-	}
-
-	args := strings.Fields(message.CommandArguments())
-	if len(args) == 0 {
-		return "Usage: /addapple <appId> [country]\nExample: /addapple com.example.app vn"
+func (c *AddAppleAppCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "addapple",
+		Description:  "Add Apple app",
+		Args:         []Arg{appIDArg, countryArg},
+		Example:      "/addapple com.example.app vn",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+		Mutating:     true,
 	}
+}
 
-	appID := args[0]
-	country := "vn"
-	if len(args) > 1 {
-		country = args[1]
-	}
+func (c *AddAppleAppCommand) Execute(req *Request) Reply {
+	groupID := req.ChatID()
+	appID := req.Args.String("appId")
+	country := req.Args.String("country")
 
 	// Verify app exists
 	app, err := c.appleScraper.GetApp(appID, country)
 	if err != nil {
-		return fmt.Sprintf("Failed to fetch app from store: %v", err)
+		return Reply{Text: fmt.Sprintf("Failed to fetch app from store: %s", api.Reason(err))}
 	}
 
-	if err := c.groupRepo.AddAppleApp(groupID, appID, country); err != nil {
-		return fmt.Sprintf("Failed to add app: %v", err)
+	primary, err := c.groupRepo.AddAppleApp(groupID, appID, country)
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to add app: %v", err)}
 	}
 
-	return fmt.Sprintf("Apple app added successfully:\n*%s*\nApp ID: %s\nCountry: %s\nScore: %.1f", app.Title, appID, country, app.Score)
+	text := fmt.Sprintf("Apple app added successfully:\n%s\nApp ID: %s\nCountry: %s\nScore: %.1f", util.Bold(app.Title), util.EscapeMarkdown(appID), country, app.Score)
+	return Reply{Text: text + storefrontNote(primary, country)}
 }
@@ -0,0 +1,97 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miti99/store-scraper-bot-go/internal/api"
+	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
+	"github.com/miti99/store-scraper-bot-go/internal/api/google"
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+)
+
+type AddDeveloperCommand struct {
+	BaseCommand
+	groupRepo     *repository.GroupRepository
+	appleScraper  *apple.AppleScraper
+	googleScraper *google.GoogleScraper
+}
+
+func NewAddDeveloperCommand(
+	cfg *config.Config,
+	groupRepo *repository.GroupRepository,
+	appleScraper *apple.AppleScraper,
+	googleScraper *google.GoogleScraper,
+) *AddDeveloperCommand {
+	return &AddDeveloperCommand{
+		BaseCommand:   BaseCommand{cfg: cfg},
+		groupRepo:     groupRepo,
+		appleScraper:  appleScraper,
+		googleScraper: googleScraper,
+	}
+}
+
+func (c *AddDeveloperCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "adddeveloper",
+		Description:  "Track all apps of a developer",
+		Args:         []Arg{storeArg, {Name: "developerId", Type: ArgString, Required: true}, countryArg},
+		Example:      "/adddeveloper apple 284882218 vn",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+		Mutating:     true,
+	}
+}
+
+func (c *AddDeveloperCommand) Execute(req *Request) Reply {
+	store := req.Args.String("store")
+	developer := model.DeveloperInfo{
+		DeveloperID: req.Args.String("developerId"),
+		Country:     req.Args.String("country"),
+	}
+
+	var added []string
+	var err error
+	if store == "apple" {
+		apps, fetchErr := c.appleScraper.GetDeveloperApps(developer.DeveloperID, developer.Country)
+		if fetchErr != nil {
+			return Reply{Text: fmt.Sprintf("Failed to fetch developer from store: %s", api.Reason(fetchErr))}
+		}
+		for _, app := range apps {
+			developer.Name = app.Developer
+			developer.AppIDs = append(developer.AppIDs, app.AppID)
+		}
+		added, err = c.groupRepo.AddAppleDeveloper(req.ChatID(), developer)
+	} else {
+		apps, fetchErr := c.googleScraper.GetDeveloperApps(developer.DeveloperID, developer.Country)
+		if fetchErr != nil {
+			return Reply{Text: fmt.Sprintf("Failed to fetch developer from store: %s", api.Reason(fetchErr))}
+		}
+		for _, app := range apps {
+			developer.Name = app.Developer
+			developer.AppIDs = append(developer.AppIDs, app.AppID)
+		}
+		added, err = c.groupRepo.AddGoogleDeveloper(req.ChatID(), developer)
+	}
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to add developer: %v", err)}
+	}
+
+	name := developer.Name
+	if name == "" {
+		name = developer.DeveloperID
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Developer added successfully:\n%s\nDeveloper ID: %s\nCountry: %s\nApps listed: %d\nNewly tracked: %d\n",
+		util.Bold(name), util.EscapeMarkdown(developer.DeveloperID), developer.Country, len(developer.AppIDs), len(added)))
+	for _, appID := range added {
+		sb.WriteString(fmt.Sprintf("- %s\n", util.EscapeMarkdown(appID)))
+	}
+	sb.WriteString("\nNew apps will be added automatically.")
+
+	return Reply{Text: sb.String()}
+}
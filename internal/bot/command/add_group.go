@@ -3,7 +3,6 @@ package command
 import (
 	"fmt"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
 	"github.com/miti99/store-scraper-bot-go/internal/repository"
 )
@@ -12,25 +11,34 @@ type AddGroupCommand struct {
 	BaseCommand
 	adminRepo *repository.AdminRepository
 	groupRepo *repository.GroupRepository
+	menus     MenuPublisher
 }
 
-func NewAddGroupCommand(cfg *config.Config, adminRepo *repository.AdminRepository, groupRepo *repository.GroupRepository) *AddGroupCommand {
+func NewAddGroupCommand(cfg *config.Config, adminRepo *repository.AdminRepository, groupRepo *repository.GroupRepository, menus MenuPublisher) *AddGroupCommand {
 	return &AddGroupCommand{
 		BaseCommand: BaseCommand{cfg: cfg},
 		adminRepo:   adminRepo,
 		groupRepo:   groupRepo,
+		menus:       menus,
 	}
 }
 
-func (c *AddGroupCommand) Execute(message *tgbotapi.Message) string {
-	if !c.requireAdmin(message) {
-		return "You are not authorized to use this command."
+func (c *AddGroupCommand) Metadata() Metadata {
+	return Metadata{
+		Name:        "addgroup",
+		Description: "Add current group to monitoring",
+		Permission:  PermissionAdmin,
+		Chat:        ChatGroup,
+		Mutating:    true,
 	}
+}
 
-	groupID := message.Chat.ID
+func (c *AddGroupCommand) Execute(req *Request) Reply {
+	groupID := req.ChatID()
 	if err := c.adminRepo.AddGroup(groupID); err != nil {
-		return fmt.Sprintf("Failed to add group: %v", err)
+		return Reply{Text: fmt.Sprintf("Failed to add group: %v", err)}
 	}
+	c.menus.PublishGroupMenu(groupID)
 
-	return fmt.Sprintf("Group %d has been added successfully.", groupID)
+	return Reply{Text: fmt.Sprintf("Group %d has been added successfully.", groupID)}
 }
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const defaultCountry = "vn"
+
+type ArgType int
+
+const (
+	// ArgString is a single word.
+	ArgString ArgType = iota
+	// ArgInt is a single integer.
+	ArgInt
+	// ArgText consumes the remaining words. Must be the last argument.
+	ArgText
+)
+
+type Arg struct {
+	Name     string
+	Type     ArgType
+	Required bool
+	Default  string
+	Choices  []string
+}
+
+var (
+	appIDArg   = Arg{Name: "appId", Type: ArgString, Required: true}
+	countryArg = Arg{Name: "country", Type: ArgString, Default: defaultCountry}
+)
+
+// Args holds parsed argument values by name.
+type Args map[string]string
+
+func (a Args) Has(name string) bool {
+	_, ok := a[name]
+	return ok
+}
+
+func (a Args) String(name string) string {
+	return a[name]
+}
+
+// Int returns an ArgInt value. Values are validated while parsing, so a
+// missing optional argument without default is the only zero case.
+func (a Args) Int(name string) int64 {
+	n, _ := strconv.ParseInt(a[name], 10, 64)
+	return n
+}
+
+func parseArgs(schema []Arg, raw string) (Args, error) {
+	fields := strings.Fields(raw)
+	args := make(Args, len(schema))
+
+	for i, arg := range schema {
+		if i >= len(fields) {
+			if arg.Required {
+				return nil, fmt.Errorf("missing argument <%s>", arg.Name)
+			}
+			if arg.Default != "" {
+				args[arg.Name] = arg.Default
+			}
+			continue
+		}
+
+		value := fields[i]
+		if arg.Type == ArgText {
+			value = strings.Join(fields[i:], " ")
+		}
+
+		if arg.Type == ArgInt {
+			if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+				return nil, fmt.Errorf("argument <%s> must be a number", arg.Name)
+			}
+		}
+
+		if len(arg.Choices) > 0 {
+			value = strings.ToLower(value)
+			if !containsString(arg.Choices, value) {
+				return nil, fmt.Errorf("argument <%s> must be one of: %s", arg.Name, strings.Join(arg.Choices, ", "))
+			}
+		}
+
+		args[arg.Name] = value
+	}
+
+	return args, nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
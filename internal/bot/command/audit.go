@@ -0,0 +1,83 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/miti99/store-scraper-bot-go/internal/audit"
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+)
+
+const (
+	defaultAuditEntries = 10
+	maxAuditEntries     = 50
+)
+
+type AuditCommand struct {
+	BaseCommand
+	auditor *audit.Auditor
+}
+
+func NewAuditCommand(cfg *config.Config, auditor *audit.Auditor) *AuditCommand {
+	return &AuditCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		auditor:     auditor,
+	}
+}
+
+func (c *AuditCommand) Metadata() Metadata {
+	return Metadata{
+		Name:        "audit",
+		Description: "Show recent changes in current group",
+		Args:        []Arg{{Name: "n", Type: ArgInt, Default: strconv.Itoa(defaultAuditEntries)}},
+		Example:     "/audit 20",
+		Permission:  PermissionAdmin,
+		Chat:        ChatGroup,
+	}
+}
+
+func (c *AuditCommand) Execute(req *Request) Reply {
+	n := req.Args.Int("n")
+	if n <= 0 {
+		return Reply{Text: c.Metadata().UsageText()}
+	}
+	limit := min(int(n), maxAuditEntries)
+
+	entries, err := c.auditor.GetRecent(req.ChatID(), limit)
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to get audit entries: %v", err)}
+	}
+
+	if len(entries) == 0 {
+		return Reply{Text: "No audit entries for this group."}
+	}
+
+	var rows [][]string
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			entry.CreatedAt.In(c.cfg.VietnamLocation).Format("01-02 15:04"),
+			fmt.Sprintf("%d", entry.UserID),
+			"/" + entry.Command,
+			util.TruncateString(entry.Arguments, 25),
+			util.TruncateString(firstLine(entry.Result), 30),
+		})
+	}
+
+	headers := []string{"Time", "User", "Command", "Args", "Result"}
+	table := util.BuildTable(headers, rows)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("*Audit Log*\nGroup: %d\nLast %d entries:\n\n", req.ChatID(), len(entries)))
+	sb.WriteString(table)
+
+	return Reply{Text: sb.String()}
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,248 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/api"
+	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
+	"github.com/miti99/store-scraper-bot-go/internal/api/google"
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/metrics"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+	"go.uber.org/zap"
+)
+
+const (
+	benchmarkVelocityDays = 30
+	benchmarkUpdateDays   = 90
+)
+
+// benchmarkEntry is one app's standing within its label.
+type benchmarkEntry struct {
+	AppID       string
+	Title       string
+	Store       string
+	Score       float64
+	Velocity    float64 // New ratings per day
+	HasVelocity bool
+	Updates     int // Store updates in the last benchmarkUpdateDays
+	Err         error
+}
+
+type BenchmarkCommand struct {
+	BaseCommand
+	groupRepo     *repository.GroupRepository
+	snapshotRepo  *repository.SnapshotRepository
+	appleScraper  *apple.AppleScraper
+	googleScraper *google.GoogleScraper
+}
+
+func NewBenchmarkCommand(
+	cfg *config.Config,
+	groupRepo *repository.GroupRepository,
+	snapshotRepo *repository.SnapshotRepository,
+	appleScraper *apple.AppleScraper,
+	googleScraper *google.GoogleScraper,
+) *BenchmarkCommand {
+	return &BenchmarkCommand{
+		BaseCommand:   BaseCommand{cfg: cfg},
+		groupRepo:     groupRepo,
+		snapshotRepo:  snapshotRepo,
+		appleScraper:  appleScraper,
+		googleScraper: googleScraper,
+	}
+}
+
+func (c *BenchmarkCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "benchmark",
+		Description:  "Rank apps by score, rating velocity and update frequency within each label",
+		Args:         []Arg{{Name: "label", Type: ArgString}},
+		Example:      "/benchmark competitor",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+	}
+}
+
+func (c *BenchmarkCommand) Execute(req *Request) Reply {
+	filter := strings.ToLower(req.Args.String("label"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := c.groupRepo.Get(ctx, req.ChatID())
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to get group: %v", err)}
+	}
+
+	byLabel := make(map[string][]benchmarkEntry)
+	for _, appInfo := range group.AppleApps {
+		if filter == "" || appInfo.LabelOrDefault() == filter {
+			byLabel[appInfo.LabelOrDefault()] = append(byLabel[appInfo.LabelOrDefault()], c.appleEntry(appInfo))
+		}
+	}
+	for _, appInfo := range group.GoogleApps {
+		if filter == "" || appInfo.LabelOrDefault() == filter {
+			byLabel[appInfo.LabelOrDefault()] = append(byLabel[appInfo.LabelOrDefault()], c.googleEntry(appInfo))
+		}
+	}
+
+	if len(byLabel) == 0 {
+		if filter != "" {
+			return Reply{Text: fmt.Sprintf("No apps labeled %s in this group.", util.EscapeMarkdown(filter))}
+		}
+		return Reply{Text: "No apps in this group."}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("*Benchmark Report*\nRatings/d over %d days, updates over %d days\n",
+		benchmarkVelocityDays, benchmarkUpdateDays))
+	for _, label := range sortedLabels(byLabel) {
+		sb.WriteString(fmt.Sprintf("\n%s\n", util.Bold(label+":")))
+		sb.WriteString(buildBenchmarkTable(byLabel[label]))
+		sb.WriteString("\n")
+	}
+
+	return Reply{Text: sb.String()}
+}
+
+func (c *BenchmarkCommand) appleEntry(appInfo model.AppInfo) benchmarkEntry {
+	entry := benchmarkEntry{AppID: appInfo.AppID, Title: appInfo.AppID, Store: "Apple"}
+
+	app, err := c.appleScraper.GetApp(appInfo.AppID, appInfo.Country)
+	if err != nil {
+		entry.Err = err
+		return entry
+	}
+	entry.Title, entry.Score = app.Title, app.Score
+
+	c.fillHistory(&entry, model.StoreApple, appInfo)
+	return entry
+}
+
+func (c *BenchmarkCommand) googleEntry(appInfo model.AppInfo) benchmarkEntry {
+	entry := benchmarkEntry{AppID: appInfo.AppID, Title: appInfo.AppID, Store: "Google"}
+
+	app, err := c.googleScraper.GetApp(appInfo.AppID, appInfo.Country)
+	if err != nil {
+		entry.Err = err
+		return entry
+	}
+	entry.Title, entry.Score = app.Title, app.Score
+
+	c.fillHistory(&entry, model.StoreGoogle, appInfo)
+	return entry
+}
+
+// fillHistory adds the snapshot based metrics. Missing history leaves them
+// empty rather than failing the whole report.
+func (c *BenchmarkCommand) fillHistory(entry *benchmarkEntry, store string, appInfo model.AppInfo) {
+	now := time.Now()
+	updateSince := now.AddDate(0, 0, -benchmarkUpdateDays)
+
+	snapshots, err := c.snapshotRepo.GetHistory(store, appInfo.AppID, appInfo.Country, updateSince)
+	if err != nil {
+		c.cfg.Logger.Error("Failed to get snapshots for benchmark",
+			zap.String("appId", appInfo.AppID),
+			zap.Error(err))
+		return
+	}
+
+	entry.Velocity, entry.HasVelocity = metrics.RatingsPerDay(metrics.Since(snapshots, now.AddDate(0, 0, -benchmarkVelocityDays)))
+	entry.Updates = metrics.UpdateCount(snapshots, updateSince)
+}
+
+// buildBenchmarkTable sorts the entries by score and shows each metric with
+// its rank within the label. Apps that could not be fetched come last.
+func buildBenchmarkTable(entries []benchmarkEntry) string {
+	ok := make([]benchmarkEntry, 0, len(entries))
+	var failed []benchmarkEntry
+	for _, e := range entries {
+		if e.Err != nil {
+			failed = append(failed, e)
+		} else {
+			ok = append(ok, e)
+		}
+	}
+
+	sort.SliceStable(ok, func(i, j int) bool { return ok[i].Score > ok[j].Score })
+
+	scoreRanks := rankBy(ok, func(e benchmarkEntry) (float64, bool) { return e.Score, true })
+	velocityRanks := rankBy(ok, func(e benchmarkEntry) (float64, bool) { return e.Velocity, e.HasVelocity })
+	updateRanks := rankBy(ok, func(e benchmarkEntry) (float64, bool) { return float64(e.Updates), true })
+
+	var rows [][]string
+	for i, e := range ok {
+		velocity := "-"
+		if e.HasVelocity {
+			velocity = fmt.Sprintf("%.1f #%d", e.Velocity, velocityRanks[i])
+		}
+		rows = append(rows, []string{
+			util.TruncateString(e.Title, 24),
+			e.Store,
+			fmt.Sprintf("%.2f #%d", e.Score, scoreRanks[i]),
+			velocity,
+			fmt.Sprintf("%d #%d", e.Updates, updateRanks[i]),
+		})
+	}
+	for _, e := range failed {
+		rows = append(rows, []string{util.TruncateString(e.AppID, 24), e.Store, api.Label(e.Err), "-", "-"})
+	}
+
+	headers := []string{"App", "Store", "Score", "Ratings/d", "Updates"}
+	return util.BuildTable(headers, rows)
+}
+
+// rankBy returns the 1-based rank of each entry, highest value first. Equal
+// values share a rank and entries without a value are not ranked.
+func rankBy(entries []benchmarkEntry, value func(benchmarkEntry) (float64, bool)) []int {
+	ranks := make([]int, len(entries))
+	for i, e := range entries {
+		v, ok := value(e)
+		if !ok {
+			continue
+		}
+		ranks[i] = 1
+		for _, other := range entries {
+			if ov, ok := value(other); ok && ov > v {
+				ranks[i]++
+			}
+		}
+	}
+	return ranks
+}
+
+// sortedLabels puts "own" and "competitor" first, then custom labels
+// alphabetically and unlabeled apps last.
+func sortedLabels(byLabel map[string][]benchmarkEntry) []string {
+	order := func(label string) int {
+		switch label {
+		case model.LabelOwn:
+			return 0
+		case model.LabelCompetitor:
+			return 1
+		case model.LabelNone:
+			return 3
+		default:
+			return 2
+		}
+	}
+
+	labels := make([]string, 0, len(byLabel))
+	for label := range byLabel {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if order(labels[i]) != order(labels[j]) {
+			return order(labels[i]) < order(labels[j])
+		}
+		return labels[i] < labels[j]
+	})
+	return labels
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"fmt"
+	"hash/crc32"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const callbackSeparator = ":"
+
+// CallbackHandler is implemented by commands that attach inline keyboards.
+// Button data has the form "<command>:<payload>" and is routed back to the
+// command that created it.
+type CallbackHandler interface {
+	HandleCallback(req *CallbackRequest) CallbackReply
+}
+
+type CallbackRequest struct {
+	Query   *tgbotapi.CallbackQuery
+	Payload string
+}
+
+func (r *CallbackRequest) UserID() int64 {
+	return r.Query.From.ID
+}
+
+func (r *CallbackRequest) ChatID() int64 {
+	return r.Query.Message.Chat.ID
+}
+
+// Fields splits the payload into its parts.
+func (r *CallbackRequest) Fields() []string {
+	return strings.Split(r.Payload, callbackSeparator)
+}
+
+// CallbackReply describes how the bot reacts to a button press.
+type CallbackReply struct {
+	// Notice is shown as a toast to the user who pressed the button.
+	Notice string
+	// Text replaces the message holding the keyboard, with Keyboard as its
+	// new keyboard. Empty keeps the message unchanged.
+	Text     string
+	Keyboard *tgbotapi.InlineKeyboardMarkup
+	// Message is sent as a new message to the chat.
+	Message string
+	// AuditArgs is set when the press changed stored state; it is recorded
+	// in the audit log as the arguments of the command.
+	AuditArgs string
+}
+
+func callbackData(command string, parts ...string) string {
+	return strings.Join(append([]string{command}, parts...), callbackSeparator)
+}
+
+// appKey identifies an app in callback data. App IDs can exceed the 64 byte
+// callback data limit, so a checksum is used instead.
+func appKey(appID string) string {
+	return fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(appID)))
+}
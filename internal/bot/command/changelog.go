@@ -0,0 +1,114 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miti99/store-scraper-bot-go/internal/api"
+	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
+	"github.com/miti99/store-scraper-bot-go/internal/api/google"
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+)
+
+const (
+	maxChangelogVersions  = 10
+	maxReleaseNotesLength = 500
+)
+
+type ChangelogCommand struct {
+	BaseCommand
+	snapshotRepo  *repository.SnapshotRepository
+	appleScraper  *apple.AppleScraper
+	googleScraper *google.GoogleScraper
+}
+
+func NewChangelogCommand(
+	cfg *config.Config,
+	snapshotRepo *repository.SnapshotRepository,
+	appleScraper *apple.AppleScraper,
+	googleScraper *google.GoogleScraper,
+) *ChangelogCommand {
+	return &ChangelogCommand{
+		BaseCommand:   BaseCommand{cfg: cfg},
+		snapshotRepo:  snapshotRepo,
+		appleScraper:  appleScraper,
+		googleScraper: googleScraper,
+	}
+}
+
+func (c *ChangelogCommand) Metadata() Metadata {
+	return Metadata{
+		Name:        "changelog",
+		Description: "Show the release notes of the last versions",
+		Args:        []Arg{storeArg, appIDArg, {Name: "n", Type: ArgInt, Default: "5"}, countryArg},
+		Example:     "/changelog apple com.example.app 3 us",
+		Permission:  PermissionAdmin,
+	}
+}
+
+func (c *ChangelogCommand) Execute(req *Request) Reply {
+	store := req.Args.String("store")
+	appID := req.Args.String("appId")
+	country := req.Args.String("country")
+
+	n := int(req.Args.Int("n"))
+	if n <= 0 || n > maxChangelogVersions {
+		return Reply{Text: fmt.Sprintf("Number of versions must be between 1 and %d.", maxChangelogVersions)}
+	}
+
+	// Fetching records a snapshot, so the current version is always listed
+	var title string
+	var fetchErr error
+	if store == model.StoreApple {
+		app, err := c.appleScraper.GetApp(appID, country)
+		if app != nil {
+			title = app.Title
+		}
+		fetchErr = err
+	} else {
+		app, err := c.googleScraper.GetApp(appID, country)
+		if app != nil {
+			title = app.Title
+		}
+		fetchErr = err
+	}
+
+	versions, err := c.snapshotRepo.GetVersions(store, appID, country, n)
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to get versions: %v", err)}
+	}
+	if len(versions) == 0 {
+		if fetchErr != nil {
+			return Reply{Text: fmt.Sprintf("Failed to fetch app: %s", api.Reason(fetchErr))}
+		}
+		return Reply{Text: fmt.Sprintf("No versions recorded for %s (%s) yet.", util.EscapeMarkdown(appID), country)}
+	}
+
+	if title == "" {
+		title = appID
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s (%s, %s)", util.Bold("Changelog of "+title), store, country))
+	for _, v := range versions {
+		date := v.FirstSeen.In(c.cfg.VietnamLocation).Format("2006-01-02")
+		if !v.Updated.IsZero() {
+			date = v.Updated.In(c.cfg.VietnamLocation).Format("2006-01-02")
+		}
+
+		notes := v.ReleaseNotes
+		if notes == "" {
+			notes = "No release notes."
+		}
+
+		sb.WriteString(fmt.Sprintf("\n\n%s - %s\n%s",
+			util.Bold(v.Version),
+			date,
+			util.EscapeMarkdown(util.TruncateString(notes, maxReleaseNotesLength))))
+	}
+
+	return Reply{Text: sb.String()}
+}
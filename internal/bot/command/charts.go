@@ -0,0 +1,149 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+)
+
+var chartArgs = []Arg{
+	storeArg,
+	{Name: "collection", Type: ArgString, Required: true, Choices: model.ChartCollections},
+	countryArg,
+	{Name: "category", Type: ArgString},
+}
+
+// chartFromArgs reads the chart from chartArgs. Google category IDs are upper
+// case, e.g. "GAME".
+func chartFromArgs(args Args) model.ChartInfo {
+	chart := model.ChartInfo{
+		Store:      args.String("store"),
+		Collection: args.String("collection"),
+		Category:   args.String("category"),
+		Country:    args.String("country"),
+	}
+	if chart.Store == model.StoreGoogle {
+		chart.Category = strings.ToUpper(chart.Category)
+	}
+	return chart
+}
+
+type AddChartCommand struct {
+	BaseCommand
+	groupRepo *repository.GroupRepository
+}
+
+func NewAddChartCommand(cfg *config.Config, groupRepo *repository.GroupRepository) *AddChartCommand {
+	return &AddChartCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		groupRepo:   groupRepo,
+	}
+}
+
+func (c *AddChartCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "addchart",
+		Description:  "Track the group's app positions in a top chart, optionally of a store category",
+		Args:         chartArgs,
+		Example:      "/addchart google topfree vn GAME",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+		Mutating:     true,
+	}
+}
+
+func (c *AddChartCommand) Execute(req *Request) Reply {
+	chart := chartFromArgs(req.Args)
+
+	if err := c.groupRepo.AddChart(req.ChatID(), chart); err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to add chart: %v", err)}
+	}
+
+	return Reply{Text: fmt.Sprintf("Chart %s (%s, %s) added. Positions are captured daily.",
+		util.EscapeMarkdown(chart.Name()), chart.Store, chart.Country)}
+}
+
+type DeleteChartCommand struct {
+	BaseCommand
+	groupRepo *repository.GroupRepository
+}
+
+func NewDeleteChartCommand(cfg *config.Config, groupRepo *repository.GroupRepository) *DeleteChartCommand {
+	return &DeleteChartCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		groupRepo:   groupRepo,
+	}
+}
+
+func (c *DeleteChartCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "deletechart",
+		Description:  "Stop tracking a top chart",
+		Args:         chartArgs,
+		Example:      "/deletechart google topfree vn GAME",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+		Mutating:     true,
+	}
+}
+
+func (c *DeleteChartCommand) Execute(req *Request) Reply {
+	chart := chartFromArgs(req.Args)
+
+	if err := c.groupRepo.RemoveChart(req.ChatID(), chart); err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to remove chart: %v", err)}
+	}
+
+	return Reply{Text: fmt.Sprintf("Chart %s (%s, %s) has been removed.",
+		util.EscapeMarkdown(chart.Name()), chart.Store, chart.Country)}
+}
+
+type ListChartsCommand struct {
+	BaseCommand
+	groupRepo *repository.GroupRepository
+}
+
+func NewListChartsCommand(cfg *config.Config, groupRepo *repository.GroupRepository) *ListChartsCommand {
+	return &ListChartsCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		groupRepo:   groupRepo,
+	}
+}
+
+func (c *ListChartsCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "listcharts",
+		Description:  "List tracked top charts",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+	}
+}
+
+func (c *ListChartsCommand) Execute(req *Request) Reply {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := c.groupRepo.Get(ctx, req.ChatID())
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to get group: %v", err)}
+	}
+
+	if len(group.Charts) == 0 {
+		return Reply{Text: "No charts in this group. Use /addchart to add one."}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("*Tracked charts:*\n")
+	for i, chart := range group.Charts {
+		sb.WriteString(fmt.Sprintf("%d. %s %s %s\n",
+			i+1, chart.Store, util.EscapeMarkdown(chart.Name()), chart.Country))
+	}
+
+	return Reply{Text: sb.String()}
+}
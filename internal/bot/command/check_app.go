@@ -6,11 +6,12 @@ import (
 	"strings"
 	"time"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/miti99/store-scraper-bot-go/internal/api"
 	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
 	"github.com/miti99/store-scraper-bot-go/internal/api/google"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
 	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/report"
 	"github.com/miti99/store-scraper-bot-go/internal/repository"
 	"github.com/miti99/store-scraper-bot-go/internal/util"
 	"go.uber.org/zap"
@@ -40,42 +41,52 @@ func NewCheckAppCommand(
 	}
 }
 
-func (c *CheckAppCommand) Execute(message *tgbotapi.Message) string {
-	if !c.requireAdmin(message) {
-		return "You are not authorized to use this command."
+func (c *CheckAppCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "checkapp",
+		Description:  "Check for non-updated apps, optionally only those with the given tags",
+		Args:         []Arg{tagFilterArg},
+		Example:      "/checkapp team:payments",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
 	}
+}
 
-	groupID := message.Chat.ID
-	hasGroup, err := c.adminRepo.HasGroup(groupID)
-	if err != nil {
-		return fmt.Sprintf("Failed to check group: %v", err)
-	}
-	if !hasGroup {
-		return "This group is not registered."
-	}
+func (c *CheckAppCommand) Execute(req *Request) Reply {
+	groupID := req.ChatID()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
 	group, err := c.groupRepo.Get(ctx, groupID)
 	if err != nil {
-		return fmt.Sprintf("Failed to get group: %v", err)
+		return Reply{Text: fmt.Sprintf("Failed to get group: %v", err)}
 	}
 
 	if len(group.AppleApps) == 0 && len(group.GoogleApps) == 0 {
-		return "No apps in this group."
+		return Reply{Text: "No apps in this group."}
+	}
+
+	tags := parseTags(req.Args.String("tags"))
+	appleApps := filterApps(group.AppleApps, tags)
+	googleApps := filterApps(group.GoogleApps, tags)
+	if len(appleApps) == 0 && len(googleApps) == 0 {
+		return Reply{Text: fmt.Sprintf("No apps tagged %s in this group.", util.EscapeMarkdown(strings.Join(tags, " ")))}
 	}
 
 	nonUpdatedApps := make([]model.NonUpdatedApp, 0)
+	failedApps := make([]model.FailedApp, 0)
 	now := time.Now().In(c.cfg.VietnamLocation)
 
 	// Check Apple apps
-	for _, appInfo := range group.AppleApps {
+	for _, appInfo := range appleApps {
 		app, err := c.appleScraper.GetApp(appInfo.AppID, appInfo.Country)
 		if err != nil {
 			c.cfg.Logger.Error("Failed to fetch Apple app",
 				zap.String("appId", appInfo.AppID),
+				zap.String("errorKind", api.Label(err)),
 				zap.Error(err))
+			failedApps = append(failedApps, model.NewFailedApp(appInfo, api.Label(err), true))
 			continue
 		}
 
@@ -85,6 +96,7 @@ func (c *CheckAppCommand) Execute(message *tgbotapi.Message) string {
 				zap.String("appId", appInfo.AppID),
 				zap.String("updated", app.Updated),
 				zap.Error(err))
+			failedApps = append(failedApps, model.NewFailedApp(appInfo, "bad date", true))
 			continue
 		}
 
@@ -104,12 +116,14 @@ func (c *CheckAppCommand) Execute(message *tgbotapi.Message) string {
 	}
 
 	// Check Google apps
-	for _, appInfo := range group.GoogleApps {
+	for _, appInfo := range googleApps {
 		app, err := c.googleScraper.GetApp(appInfo.AppID, appInfo.Country)
 		if err != nil {
 			c.cfg.Logger.Error("Failed to fetch Google app",
 				zap.String("appId", appInfo.AppID),
+				zap.String("errorKind", api.Label(err)),
 				zap.Error(err))
+			failedApps = append(failedApps, model.NewFailedApp(appInfo, api.Label(err), false))
 			continue
 		}
 
@@ -130,8 +144,8 @@ func (c *CheckAppCommand) Execute(message *tgbotapi.Message) string {
 		}
 	}
 
-	if len(nonUpdatedApps) == 0 {
-		return fmt.Sprintf("All apps are up to date (checked within %d days).", c.cfg.NumDaysWarningNotUpdated)
+	if len(nonUpdatedApps) == 0 && len(failedApps) == 0 {
+		return Reply{Text: fmt.Sprintf("All apps are up to date (checked within %d days).", c.cfg.NumDaysWarningNotUpdated)}
 	}
 
 	// Build table
@@ -157,9 +171,12 @@ func (c *CheckAppCommand) Execute(message *tgbotapi.Message) string {
 	table := util.BuildTable(headers, rows)
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("*Non-Updated Apps Report*\nGroup: %d\nApps not updated in >%d days: *%d*\n\n",
-		groupID, c.cfg.NumDaysWarningNotUpdated, len(nonUpdatedApps)))
+	sb.WriteString(fmt.Sprintf("*Non-Updated Apps Report*\nGroup: %d\n", groupID))
+	if len(tags) > 0 {
+		sb.WriteString(fmt.Sprintf("Tags: %s\n", util.EscapeMarkdown(strings.Join(tags, " "))))
+	}
+	sb.WriteString(fmt.Sprintf("Apps not updated in >%d days: *%d*\n\n", c.cfg.NumDaysWarningNotUpdated, len(nonUpdatedApps)))
 	sb.WriteString(table)
 
-	return sb.String()
+	return Reply{Text: strings.TrimRight(sb.String(), "\n") + report.FailedSection(failedApps)}
 }
@@ -3,21 +3,62 @@ package command
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/miti99/store-scraper-bot-go/internal/api"
 	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
 	"github.com/miti99/store-scraper-bot-go/internal/api/google"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/metrics"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
 	"github.com/miti99/store-scraper-bot-go/internal/repository"
 	"github.com/miti99/store-scraper-bot-go/internal/util"
+	"go.uber.org/zap"
 )
 
+// sortPrefix marks the sort field among the filter tags, e.g. "sort:ratings7".
+const sortPrefix = "sort:"
+
+// scoreSortFields maps the accepted sort fields to the value they order by.
+var scoreSortFields = map[string]func(scoreRow) model.Rate{
+	"score":     func(r scoreRow) model.Rate { return model.Rate{Value: r.Score, OK: true} },
+	"delta":     func(r scoreRow) model.Rate { return r.Trend.ScoreDelta30 },
+	"ratings":   func(r scoreRow) model.Rate { return model.Rate{Value: float64(r.Ratings), OK: true} },
+	"ratings7":  func(r scoreRow) model.Rate { return r.Trend.RatingsPerDay7 },
+	"ratings30": func(r scoreRow) model.Rate { return r.Trend.RatingsPerDay30 },
+	"reviews":   func(r scoreRow) model.Rate { return model.Rate{Value: float64(r.Reviews), OK: true} },
+	"reviews7":  func(r scoreRow) model.Rate { return r.Trend.ReviewsPerDay7 },
+	"reviews30": func(r scoreRow) model.Rate { return r.Trend.ReviewsPerDay30 },
+}
+
+// scoreSortFieldNames returns the accepted sort fields in alphabetical order.
+func scoreSortFieldNames() []string {
+	names := make([]string, 0, len(scoreSortFields))
+	for name := range scoreSortFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type scoreRow struct {
+	AppID   string
+	Title   string
+	Store   string
+	Score   float64
+	Reviews int64
+	Ratings int64
+	Trend   model.Trend
+	Err     error
+}
+
 type CheckAppScoresCommand struct {
 	BaseCommand
 	adminRepo     *repository.AdminRepository
 	groupRepo     *repository.GroupRepository
+	snapshotRepo  *repository.SnapshotRepository
 	appleScraper  *apple.AppleScraper
 	googleScraper *google.GoogleScraper
 }
@@ -26,6 +67,7 @@ func NewCheckAppScoresCommand(
 	cfg *config.Config,
 	adminRepo *repository.AdminRepository,
 	groupRepo *repository.GroupRepository,
+	snapshotRepo *repository.SnapshotRepository,
 	appleScraper *apple.AppleScraper,
 	googleScraper *google.GoogleScraper,
 ) *CheckAppScoresCommand {
@@ -33,23 +75,32 @@ func NewCheckAppScoresCommand(
 		BaseCommand:   BaseCommand{cfg: cfg},
 		adminRepo:     adminRepo,
 		groupRepo:     groupRepo,
+		snapshotRepo:  snapshotRepo,
 		appleScraper:  appleScraper,
 		googleScraper: googleScraper,
 	}
 }
 
-func (c *CheckAppScoresCommand) Execute(message *tgbotapi.Message) string {
-	if !c.requireAdmin(message) {
-		return "You are not authorized to use this command."
+func (c *CheckAppScoresCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "checkappscores",
+		Description:  "Check app scores and growth, optionally only those with the given tags. Add sort:<field> to order by score, delta, ratings, ratings7, ratings30, reviews, reviews7 or reviews30",
+		Args:         []Arg{tagFilterArg},
+		Example:      "/checkappscores team:payments sort:ratings7",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
 	}
+}
 
-	groupID := message.Chat.ID
-	hasGroup, err := c.adminRepo.HasGroup(groupID)
-	if err != nil {
-		return fmt.Sprintf("Failed to check group: %v", err)
-	}
-	if !hasGroup {
-		return "This group is not registered."
+func (c *CheckAppScoresCommand) Execute(req *Request) Reply {
+	groupID := req.ChatID()
+
+	sortField, tags := splitSortField(parseTags(req.Args.String("tags")))
+	if sortField != "" {
+		if _, ok := scoreSortFields[sortField]; !ok {
+			return Reply{Text: fmt.Sprintf("Invalid sort field: %s\nValid fields: %s\n%s",
+				util.EscapeMarkdown(sortField), strings.Join(scoreSortFieldNames(), ", "), c.Metadata().UsageText())}
+		}
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -57,67 +108,134 @@ func (c *CheckAppScoresCommand) Execute(message *tgbotapi.Message) string {
 
 	group, err := c.groupRepo.Get(ctx, groupID)
 	if err != nil {
-		return fmt.Sprintf("Failed to get group: %v", err)
+		return Reply{Text: fmt.Sprintf("Failed to get group: %v", err)}
 	}
 
 	if len(group.AppleApps) == 0 && len(group.GoogleApps) == 0 {
-		return "No apps in this group."
+		return Reply{Text: "No apps in this group."}
 	}
 
-	var rows [][]string
+	appleApps := filterApps(group.AppleApps, tags)
+	googleApps := filterApps(group.GoogleApps, tags)
+	if len(appleApps) == 0 && len(googleApps) == 0 {
+		return Reply{Text: fmt.Sprintf("No apps tagged %s in this group.", util.EscapeMarkdown(strings.Join(tags, " ")))}
+	}
+
+	scoreRows := make([]scoreRow, 0, len(appleApps)+len(googleApps))
 
 	// Check Apple apps
-	for _, appInfo := range group.AppleApps {
+	for _, appInfo := range appleApps {
+		row := scoreRow{AppID: appInfo.AppID, Store: "Apple"}
 		app, err := c.appleScraper.GetApp(appInfo.AppID, appInfo.Country)
 		if err != nil {
-			rows = append(rows, []string{
-				util.TruncateString(appInfo.AppID, 30),
-				"Apple",
-				"Error",
-				"0",
-				"0",
-			})
-			continue
+			row.Err = err
+		} else {
+			row.Title, row.Score, row.Reviews, row.Ratings = app.Title, app.Score, int64(app.Reviews), app.Ratings
+			row.Trend = c.trend(model.StoreApple, appInfo)
 		}
-
-		rows = append(rows, []string{
-			util.TruncateString(app.Title, 30),
-			"Apple",
-			fmt.Sprintf("%.1f", app.Score),
-			fmt.Sprintf("%d", app.Reviews),
-			util.FormatNumber(app.Ratings),
-		})
+		scoreRows = append(scoreRows, row)
 	}
 
 	// Check Google apps
-	for _, appInfo := range group.GoogleApps {
+	for _, appInfo := range googleApps {
+		row := scoreRow{AppID: appInfo.AppID, Store: "Google"}
 		app, err := c.googleScraper.GetApp(appInfo.AppID, appInfo.Country)
 		if err != nil {
+			row.Err = err
+		} else {
+			row.Title, row.Score, row.Reviews, row.Ratings = app.Title, app.Score, app.Reviews, app.Ratings
+			row.Trend = c.trend(model.StoreGoogle, appInfo)
+		}
+		scoreRows = append(scoreRows, row)
+	}
+
+	if sortField != "" {
+		sortScoreRows(scoreRows, scoreSortFields[sortField])
+	}
+
+	var rows [][]string
+	for _, row := range scoreRows {
+		if row.Err != nil {
 			rows = append(rows, []string{
-				util.TruncateString(appInfo.AppID, 30),
-				"Google",
-				"Error",
-				"0",
-				"0",
+				util.TruncateString(row.AppID, 24),
+				row.Store,
+				api.Label(row.Err),
+				"-", "-", "-", "-", "-", "-", "-",
 			})
 			continue
 		}
 
 		rows = append(rows, []string{
-			util.TruncateString(app.Title, 30),
-			"Google",
-			fmt.Sprintf("%.1f", app.Score),
-			fmt.Sprintf("%d", app.Reviews),
-			util.FormatNumber(app.Ratings),
+			util.TruncateString(row.Title, 24),
+			row.Store,
+			fmt.Sprintf("%.1f", row.Score),
+			row.Trend.ScoreDelta30.Format("%+.2f"),
+			util.FormatNumber(row.Ratings),
+			row.Trend.RatingsPerDay7.Format("%.1f"),
+			row.Trend.RatingsPerDay30.Format("%.1f"),
+			fmt.Sprintf("%d", row.Reviews),
+			row.Trend.ReviewsPerDay7.Format("%.1f"),
+			row.Trend.ReviewsPerDay30.Format("%.1f"),
 		})
 	}
 
-	headers := []string{"App", "Store", "Score", "Reviews", "Ratings"}
+	headers := []string{"App", "Store", "Score", "Delta30d", "Ratings", "Rt/d 7d", "Rt/d 30d", "Reviews", "Rv/d 7d", "Rv/d 30d"}
 	table := util.BuildTable(headers, rows)
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("*App Scores Report*\nGroup: %d\n\n", groupID))
+	sb.WriteString(fmt.Sprintf("*App Scores Report*\nGroup: %d\n", groupID))
+	if len(tags) > 0 {
+		sb.WriteString(fmt.Sprintf("Tags: %s\n", util.EscapeMarkdown(strings.Join(tags, " "))))
+	}
+	if sortField != "" {
+		sb.WriteString(fmt.Sprintf("Sorted by: %s\n", sortField))
+	}
+	sb.WriteString("\n")
 	sb.WriteString(table)
 
-	return sb.String()
+	return Reply{Text: sb.String()}
+}
+
+// trend computes the growth metrics of the app's primary storefront. Missing
+// history only leaves the metrics empty.
+func (c *CheckAppScoresCommand) trend(store string, appInfo model.AppInfo) model.Trend {
+	now := time.Now()
+	snapshots, err := c.snapshotRepo.GetHistory(store, appInfo.AppID, appInfo.Country, now.AddDate(0, 0, -metrics.TrendDays))
+	if err != nil {
+		c.cfg.Logger.Error("Failed to get snapshots",
+			zap.String("appId", appInfo.AppID),
+			zap.Error(err))
+		return model.Trend{}
+	}
+	return metrics.Compute(snapshots, now)
+}
+
+// splitSortField takes the sort:<field> token out of the filter tags. /tag
+// rejects tags with the prefix, so no filter tag is mistaken for it.
+func splitSortField(tags []string) (string, []string) {
+	sortField := ""
+	rest := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, sortPrefix) {
+			sortField = strings.TrimPrefix(tag, sortPrefix)
+			continue
+		}
+		rest = append(rest, tag)
+	}
+	return sortField, rest
+}
+
+// sortScoreRows orders by the value descending. Unknown values and apps that
+// could not be fetched go last.
+func sortScoreRows(rows []scoreRow, value func(scoreRow) model.Rate) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if (rows[i].Err == nil) != (rows[j].Err == nil) {
+			return rows[i].Err == nil
+		}
+		a, b := value(rows[i]), value(rows[j])
+		if a.OK != b.OK {
+			return a.OK
+		}
+		return a.Value > b.Value
+	})
 }
@@ -1,25 +1,125 @@
 package command
 
 import (
+	"fmt"
+	"strings"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
 )
 
-type Command interface {
-	Execute(message *tgbotapi.Message) string
+// Permission is the minimum role required to run a command.
+type Permission int
+
+const (
+	PermissionEveryone Permission = iota
+	PermissionAdmin
+	PermissionCreator
+)
+
+func (p Permission) String() string {
+	switch p {
+	case PermissionAdmin:
+		return "admin"
+	case PermissionCreator:
+		return "creator"
+	default:
+		return "everyone"
+	}
 }
 
-type BaseCommand struct {
-	cfg *config.Config
+// ChatType restricts where a command is offered in the Telegram menu.
+type ChatType int
+
+const (
+	ChatAny ChatType = iota
+	ChatGroup
+	ChatPrivate
+)
+
+// Metadata describes a command declaratively. The registry uses it for
+// permission and group checks, argument parsing, auditing and /help.
+type Metadata struct {
+	Name         string
+	Description  string
+	Args         []Arg
+	Example      string
+	Permission   Permission
+	RequireGroup bool
+	Mutating     bool
+	// Chat is the chat type the command is meant for. Commands requiring a
+	// registered group are always group commands.
+	Chat ChatType
+}
+
+// AvailableIn reports whether the command belongs in the menu of the chat type.
+func (m Metadata) AvailableIn(chat ChatType) bool {
+	if m.RequireGroup {
+		return chat == ChatGroup
+	}
+	return m.Chat == ChatAny || m.Chat == chat
 }
 
-func (c *BaseCommand) isAdmin(userID int64) bool {
-	return c.cfg.IsAdmin(userID)
+// Usage returns the command signature, e.g. "/addapple <appId> [country]".
+func (m Metadata) Usage() string {
+	var sb strings.Builder
+	sb.WriteString("/" + m.Name)
+	for _, arg := range m.Args {
+		if arg.Required {
+			sb.WriteString(fmt.Sprintf(" <%s>", arg.Name))
+		} else {
+			sb.WriteString(fmt.Sprintf(" [%s]", arg.Name))
+		}
+	}
+	return sb.String()
 }
 
-func (c *BaseCommand) requireAdmin(message *tgbotapi.Message) bool {
-	if !c.isAdmin(message.From.ID) {
-		return false
+// UsageText is the reply sent when a command is called with invalid arguments.
+func (m Metadata) UsageText() string {
+	text := "Usage: " + m.Usage()
+	if m.Example != "" {
+		text += "\nExample: " + m.Example
 	}
-	return true
+	return text
+}
+
+type Command interface {
+	Metadata() Metadata
+	Execute(req *Request) Reply
+}
+
+// MenuPublisher updates the Telegram command menus after the admins or the
+// registered groups change.
+type MenuPublisher interface {
+	PublishAdminMenu(userID int64)
+	ClearAdminMenu(userID int64)
+	PublishGroupMenu(groupID int64)
+	ClearGroupMenu(groupID int64)
+}
+
+// Request is a single command invocation with its parsed arguments.
+type Request struct {
+	Message *tgbotapi.Message
+	Args    Args
+}
+
+// Reply is what the bot sends back for a command.
+type Reply struct {
+	Text string
+	// Keyboard is attached to the reply text.
+	Keyboard *tgbotapi.InlineKeyboardMarkup
+	// Photo is a PNG image sent after the reply text.
+	Photo []byte
+}
+
+func (r *Request) UserID() int64 {
+	return r.Message.From.ID
+}
+
+func (r *Request) ChatID() int64 {
+	return r.Message.Chat.ID
+}
+
+type BaseCommand struct {
+	cfg *config.Config
 }
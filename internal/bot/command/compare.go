@@ -0,0 +1,194 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/api"
+	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
+	"github.com/miti99/store-scraper-bot-go/internal/api/google"
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+)
+
+// storefront is one country's listing of an app, for side by side comparison.
+type storefront struct {
+	Country string
+	Score   float64
+	Ratings int64
+	Version string
+	Updated string
+	Err     error
+}
+
+type CompareCommand struct {
+	BaseCommand
+	groupRepo     *repository.GroupRepository
+	appleScraper  *apple.AppleScraper
+	googleScraper *google.GoogleScraper
+}
+
+func NewCompareCommand(
+	cfg *config.Config,
+	groupRepo *repository.GroupRepository,
+	appleScraper *apple.AppleScraper,
+	googleScraper *google.GoogleScraper,
+) *CompareCommand {
+	return &CompareCommand{
+		BaseCommand:   BaseCommand{cfg: cfg},
+		groupRepo:     groupRepo,
+		appleScraper:  appleScraper,
+		googleScraper: googleScraper,
+	}
+}
+
+func (c *CompareCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "compare",
+		Description:  "Compare an app across its tracked countries",
+		Args:         []Arg{appIDArg},
+		Example:      "/compare com.example.app",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+	}
+}
+
+func (c *CompareCommand) Execute(req *Request) Reply {
+	appID := req.Args.String("appId")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := c.groupRepo.Get(ctx, req.ChatID())
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to get group: %v", err)}
+	}
+
+	appleCountries := storefrontsOf(group.AppleApps, appID)
+	googleCountries := storefrontsOf(group.GoogleApps, appID)
+	if len(appleCountries) == 0 && len(googleCountries) == 0 {
+		return Reply{Text: fmt.Sprintf("App %s is not tracked in this group.", util.EscapeMarkdown(appID))}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("*Storefront Comparison*\nApp: %s\n", util.EscapeMarkdown(appID)))
+
+	if len(appleCountries) > 0 {
+		storefronts := make([]storefront, 0, len(appleCountries))
+		for _, country := range appleCountries {
+			sf := storefront{Country: country}
+			app, err := c.appleScraper.GetApp(appID, country)
+			if err != nil {
+				sf.Err = err
+			} else {
+				sf.Score, sf.Ratings, sf.Version = app.Score, app.Ratings, app.Version
+				if len(app.Updated) >= 10 {
+					sf.Updated = app.Updated[:10]
+				}
+			}
+			storefronts = append(storefronts, sf)
+		}
+		sb.WriteString("\n*Apple:*\n")
+		sb.WriteString(buildComparisonTable(storefronts))
+		sb.WriteString("\n")
+	}
+
+	if len(googleCountries) > 0 {
+		storefronts := make([]storefront, 0, len(googleCountries))
+		for _, country := range googleCountries {
+			sf := storefront{Country: country}
+			app, err := c.googleScraper.GetApp(appID, country)
+			if err != nil {
+				sf.Err = err
+			} else {
+				sf.Score, sf.Ratings, sf.Version = app.Score, app.Ratings, app.Version
+				sf.Updated = time.UnixMilli(app.Updated).Format("2006-01-02")
+			}
+			storefronts = append(storefronts, sf)
+		}
+		sb.WriteString("\n*Google:*\n")
+		sb.WriteString(buildComparisonTable(storefronts))
+		sb.WriteString("\n")
+	}
+
+	return Reply{Text: sb.String()}
+}
+
+// buildComparisonTable renders storefronts side by side and marks those whose
+// version is behind the newest one, i.e. where the rollout has not landed.
+func buildComparisonTable(storefronts []storefront) string {
+	latest := ""
+	for _, sf := range storefronts {
+		if sf.Err == nil && isComparableVersion(sf.Version) && util.CompareVersions(sf.Version, latest) > 0 {
+			latest = sf.Version
+		}
+	}
+
+	var rows [][]string
+	behind := 0
+	for _, sf := range storefronts {
+		if sf.Err != nil {
+			rows = append(rows, []string{sf.Country, "-", "-", "-", "-", api.Label(sf.Err)})
+			continue
+		}
+
+		status := "latest"
+		if !isComparableVersion(sf.Version) {
+			status = "-"
+		} else if util.CompareVersions(sf.Version, latest) < 0 {
+			status = "BEHIND"
+			behind++
+		}
+		rows = append(rows, []string{
+			sf.Country,
+			fmt.Sprintf("%.2f", sf.Score),
+			util.FormatNumber(sf.Ratings),
+			sf.Version,
+			sf.Updated,
+			status,
+		})
+	}
+
+	headers := []string{"Country", "Score", "Ratings", "Version", "Updated", "Status"}
+	table := util.BuildTable(headers, rows)
+	if behind > 0 {
+		table += fmt.Sprintf("\nRollout pending in %d of %d storefronts (latest %s)", behind, len(storefronts), latest)
+	}
+	return table
+}
+
+// storefrontsOf collects the countries an app is tracked in, across entries.
+func storefrontsOf(apps []model.AppInfo, appID string) []string {
+	var countries []string
+	for _, app := range apps {
+		if app.AppID != appID {
+			continue
+		}
+		for _, country := range app.Storefronts() {
+			if !containsString(countries, country) {
+				countries = append(countries, country)
+			}
+		}
+	}
+	return countries
+}
+
+// isComparableVersion filters out placeholders such as Google's
+// "Varies with device".
+func isComparableVersion(version string) bool {
+	return version != "" && version[0] >= '0' && version[0] <= '9'
+}
+
+// storefrontNote explains that a country added to an app tracked already is
+// only compared, as the scheduled checks use the primary country.
+func storefrontNote(primary, country string) string {
+	if primary == "" || primary == country {
+		return ""
+	}
+	return fmt.Sprintf("\n\nThe app is already tracked in %s, so %s was added as an extra storefront. "+
+		"Scheduled checks use %s only; use /compare to see all countries.", primary, country, primary)
+}
@@ -2,11 +2,10 @@ package command
 
 import (
 	"fmt"
-	"strings"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
 	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
 )
 
 type DeleteAppleAppCommand struct {
@@ -23,30 +22,32 @@ func NewDeleteAppleAppCommand(cfg *config.Config, adminRepo *repository.AdminRep
 	}
 }
 
-func (c *DeleteAppleAppCommand) Execute(message *tgbotapi.Message) string {
-	if !c.requireAdmin(message) {
-		return "You are not authorized to use this command."
+func (c *DeleteAppleAppCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "deleteapple",
+		Description:  "Remove Apple app, or only one country",
+		Args:         []Arg{appIDArg, {Name: "country", Type: ArgString}},
+		Example:      "/deleteapple com.example.app us",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+		Mutating:     true,
 	}
+}
 
-	groupID := message.Chat.ID
-	hasGroup, err := c.adminRepo.HasGroup(groupID)
-	if err != nil {
-		return fmt.Sprintf("Failed to check group: %v", err)
-	}
-	if !hasGroup {
-		return "This group is not registered."
-	}
+func (c *DeleteAppleAppCommand) Execute(req *Request) Reply {
+	appID := req.Args.String("appId")
 
-	args := strings.Fields(message.CommandArguments())
-	if len(args) == 0 {
-		return "Usage: /deleteapple <appId>\nExample: /deleteapple com.example.app"
+	if req.Args.Has("country") {
+		country := req.Args.String("country")
+		if err := c.groupRepo.RemoveAppleStorefront(req.ChatID(), appID, country); err != nil {
+			return Reply{Text: fmt.Sprintf("Failed to remove app: %v", err)}
+		}
+		return Reply{Text: fmt.Sprintf("Apple app %s has been removed from %s successfully.", util.EscapeMarkdown(appID), country)}
 	}
 
-	appID := args[0]
-
-	if err := c.groupRepo.RemoveAppleApp(groupID, appID); err != nil {
-		return fmt.Sprintf("Failed to remove app: %v", err)
+	if err := c.groupRepo.RemoveAppleApp(req.ChatID(), appID); err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to remove app: %v", err)}
 	}
 
-	return fmt.Sprintf("Apple app %s has been removed successfully.", appID)
+	return Reply{Text: fmt.Sprintf("Apple app %s has been removed successfully.", util.EscapeMarkdown(appID))}
 }
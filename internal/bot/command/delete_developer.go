@@ -0,0 +1,50 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+)
+
+type DeleteDeveloperCommand struct {
+	BaseCommand
+	groupRepo *repository.GroupRepository
+}
+
+func NewDeleteDeveloperCommand(cfg *config.Config, groupRepo *repository.GroupRepository) *DeleteDeveloperCommand {
+	return &DeleteDeveloperCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		groupRepo:   groupRepo,
+	}
+}
+
+func (c *DeleteDeveloperCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "deletedeveloper",
+		Description:  "Stop tracking a developer, keeping its apps",
+		Args:         []Arg{storeArg, {Name: "developerId", Type: ArgString, Required: true}, countryArg},
+		Example:      "/deletedeveloper apple 284882218 vn",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+		Mutating:     true,
+	}
+}
+
+func (c *DeleteDeveloperCommand) Execute(req *Request) Reply {
+	developerID := req.Args.String("developerId")
+	country := req.Args.String("country")
+
+	var err error
+	if req.Args.String("store") == "apple" {
+		err = c.groupRepo.RemoveAppleDeveloper(req.ChatID(), developerID, country)
+	} else {
+		err = c.groupRepo.RemoveGoogleDeveloper(req.ChatID(), developerID, country)
+	}
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to remove developer: %v", err)}
+	}
+
+	return Reply{Text: fmt.Sprintf("Developer %s (%s) has been removed successfully. Its apps are still tracked.", util.EscapeMarkdown(developerID), country)}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
 	"github.com/miti99/store-scraper-bot-go/internal/repository"
 )
@@ -14,33 +13,43 @@ type DeleteGroupCommand struct {
 	BaseCommand
 	adminRepo *repository.AdminRepository
 	groupRepo *repository.GroupRepository
+	menus     MenuPublisher
 }
 
-func NewDeleteGroupCommand(cfg *config.Config, adminRepo *repository.AdminRepository, groupRepo *repository.GroupRepository) *DeleteGroupCommand {
+func NewDeleteGroupCommand(cfg *config.Config, adminRepo *repository.AdminRepository, groupRepo *repository.GroupRepository, menus MenuPublisher) *DeleteGroupCommand {
 	return &DeleteGroupCommand{
 		BaseCommand: BaseCommand{cfg: cfg},
 		adminRepo:   adminRepo,
 		groupRepo:   groupRepo,
+		menus:       menus,
 	}
 }
 
-func (c *DeleteGroupCommand) Execute(message *tgbotapi.Message) string {
-	if !c.requireAdmin(message) {
-		return "You are not authorized to use this command."
+func (c *DeleteGroupCommand) Metadata() Metadata {
+	return Metadata{
+		Name:        "deletegroup",
+		Description: "Remove current group",
+		Permission:  PermissionAdmin,
+		Chat:        ChatGroup,
+		Mutating:    true,
 	}
+}
 
-	groupID := message.Chat.ID
+func (c *DeleteGroupCommand) Execute(req *Request) Reply {
+	groupID := req.ChatID()
 
 	if err := c.adminRepo.RemoveGroup(groupID); err != nil {
-		return fmt.Sprintf("Failed to remove group: %v", err)
+		return Reply{Text: fmt.Sprintf("Failed to remove group: %v", err)}
 	}
 
+	c.menus.ClearGroupMenu(groupID)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := c.groupRepo.Delete(ctx, groupID); err != nil {
-		return fmt.Sprintf("Group removed from admin but failed to delete group data: %v", err)
+		return Reply{Text: fmt.Sprintf("Group removed from admin but failed to delete group data: %v", err)}
 	}
 
-	return fmt.Sprintf("Group %d has been deleted successfully.", groupID)
+	return Reply{Text: fmt.Sprintf("Group %d has been deleted successfully.", groupID)}
 }
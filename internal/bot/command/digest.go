@@ -0,0 +1,65 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+)
+
+type DigestCommand struct {
+	BaseCommand
+	groupRepo *repository.GroupRepository
+}
+
+func NewDigestCommand(cfg *config.Config, groupRepo *repository.GroupRepository) *DigestCommand {
+	return &DigestCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		groupRepo:   groupRepo,
+	}
+}
+
+func (c *DigestCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "digest",
+		Description:  "Show or choose the digest reports of the group: off, weekly, monthly or both",
+		Args:         []Arg{{Name: "setting", Type: ArgString, Choices: model.DigestSettings}},
+		Example:      "/digest weekly",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+		Mutating:     true,
+	}
+}
+
+func (c *DigestCommand) Execute(req *Request) Reply {
+	if !req.Args.Has("setting") {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		group, err := c.groupRepo.Get(ctx, req.ChatID())
+		if err != nil {
+			return Reply{Text: fmt.Sprintf("Failed to get group: %v", err)}
+		}
+		return Reply{Text: fmt.Sprintf("Digest: %s\n%s", group.DigestOrDefault(), c.Metadata().UsageText())}
+	}
+
+	setting := req.Args.String("setting")
+	if err := c.groupRepo.SetDigest(req.ChatID(), setting); err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to set digest: %v", err)}
+	}
+
+	if setting == model.DigestOff {
+		return Reply{Text: "Digest reports are turned off for this group."}
+	}
+	return Reply{Text: fmt.Sprintf("This group now gets the %s digest.", digestDescription(setting))}
+}
+
+func digestDescription(setting string) string {
+	if setting == model.DigestBoth {
+		return "weekly and monthly"
+	}
+	return setting
+}
@@ -0,0 +1,65 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+)
+
+type HelpCommand struct {
+	BaseCommand
+	registry *Registry
+}
+
+func NewHelpCommand(cfg *config.Config, registry *Registry) *HelpCommand {
+	return &HelpCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		registry:    registry,
+	}
+}
+
+func (c *HelpCommand) Metadata() Metadata {
+	return Metadata{
+		Name:        "help",
+		Description: "Show available commands or details of one command",
+		Args:        []Arg{{Name: "command", Type: ArgString}},
+		Example:     "/help addapple",
+		Permission:  PermissionEveryone,
+	}
+}
+
+func (c *HelpCommand) Execute(req *Request) Reply {
+	if !req.Args.Has("command") {
+		return Reply{Text: "*Commands:*\n" + commandList(c.registry.CommandsFor(req.UserID()))}
+	}
+
+	name := strings.TrimPrefix(req.Args.String("command"), "/")
+	cmd, ok := c.registry.Get(name)
+	if !ok {
+		return Reply{Text: fmt.Sprintf("Unknown command: /%s", name)}
+	}
+
+	meta := cmd.Metadata()
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("*/%s*\n%s\n\n", meta.Name, meta.Description))
+	sb.WriteString(fmt.Sprintf("Usage: %s\n", meta.Usage()))
+	if meta.Example != "" {
+		sb.WriteString(fmt.Sprintf("Example: %s\n", meta.Example))
+	}
+	sb.WriteString(fmt.Sprintf("Permission: %s\n", meta.Permission))
+	if meta.RequireGroup {
+		sb.WriteString("Requires a registered group\n")
+	}
+
+	return Reply{Text: sb.String()}
+}
+
+func commandList(commands []Command) string {
+	var sb strings.Builder
+	for _, cmd := range commands {
+		meta := cmd.Metadata()
+		sb.WriteString(fmt.Sprintf("%s - %s\n", meta.Usage(), meta.Description))
+	}
+	return sb.String()
+}
@@ -0,0 +1,129 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/api"
+	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
+	"github.com/miti99/store-scraper-bot-go/internal/api/google"
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+)
+
+const histogramBarWidth = 20
+
+type HistogramCommand struct {
+	BaseCommand
+	snapshotRepo  *repository.SnapshotRepository
+	appleScraper  *apple.AppleScraper
+	googleScraper *google.GoogleScraper
+}
+
+func NewHistogramCommand(
+	cfg *config.Config,
+	snapshotRepo *repository.SnapshotRepository,
+	appleScraper *apple.AppleScraper,
+	googleScraper *google.GoogleScraper,
+) *HistogramCommand {
+	return &HistogramCommand{
+		BaseCommand:   BaseCommand{cfg: cfg},
+		snapshotRepo:  snapshotRepo,
+		appleScraper:  appleScraper,
+		googleScraper: googleScraper,
+	}
+}
+
+func (c *HistogramCommand) Metadata() Metadata {
+	return Metadata{
+		Name:        "histogram",
+		Description: "Show the star rating distribution and its change since the previous snapshot",
+		Args:        []Arg{storeArg, appIDArg, countryArg},
+		Example:     "/histogram google com.example.app vn",
+		Permission:  PermissionAdmin,
+	}
+}
+
+func (c *HistogramCommand) Execute(req *Request) Reply {
+	store := req.Args.String("store")
+	appID := req.Args.String("appId")
+	country := req.Args.String("country")
+
+	var title string
+	var histogram map[string]int64
+	if store == model.StoreApple {
+		app, err := c.appleScraper.GetApp(appID, country)
+		if err != nil {
+			return Reply{Text: fmt.Sprintf("Failed to fetch app: %s", api.Reason(err))}
+		}
+		title, histogram = app.Title, app.Histogram
+	} else {
+		app, err := c.googleScraper.GetApp(appID, country)
+		if err != nil {
+			return Reply{Text: fmt.Sprintf("Failed to fetch app: %s", api.Reason(err))}
+		}
+		title, histogram = app.Title, app.Histogram
+	}
+
+	if len(histogram) == 0 {
+		return Reply{Text: fmt.Sprintf("No rating histogram available for %s.", util.EscapeMarkdown(appID))}
+	}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	previous, err := c.snapshotRepo.GetLatestBefore(store, appID, country, today)
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to get previous snapshot: %v", err)}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s\nStore: %s\nCountry: %s\n", util.Bold(title), store, country))
+	if previous != nil && len(previous.Histogram) > 0 {
+		sb.WriteString(fmt.Sprintf("Change since: %s\n", previous.Date))
+		sb.WriteString("\n")
+		sb.WriteString(buildHistogramChart(histogram, previous.Histogram))
+	} else {
+		sb.WriteString("\n")
+		sb.WriteString(buildHistogramChart(histogram, nil))
+	}
+
+	return Reply{Text: sb.String()}
+}
+
+// buildHistogramChart renders the 5 to 1 star counts as text bars scaled to
+// the total, with the change against previous when given.
+func buildHistogramChart(histogram, previous map[string]int64) string {
+	var total int64
+	for _, count := range histogram {
+		total += count
+	}
+
+	var sb strings.Builder
+	sb.WriteString("```\n")
+	for star := 5; star >= 1; star-- {
+		key := fmt.Sprintf("%d", star)
+		count := histogram[key]
+
+		share := 0.0
+		if total > 0 {
+			share = float64(count) / float64(total)
+		}
+		filled := int(share*histogramBarWidth + 0.5)
+
+		sb.WriteString(fmt.Sprintf("%d★ %s%s %5.1f%% %s",
+			star,
+			strings.Repeat("█", filled),
+			strings.Repeat("░", histogramBarWidth-filled),
+			share*100,
+			util.FormatNumber(count)))
+		if previous != nil {
+			sb.WriteString(fmt.Sprintf(" (%+d)", count-previous[key]))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("Total: %s\n", util.FormatNumber(total)))
+	sb.WriteString("```")
+	return sb.String()
+}
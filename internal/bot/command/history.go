@@ -0,0 +1,69 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/chart"
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+	"go.uber.org/zap"
+)
+
+const maxHistoryDays = 365
+
+type HistoryCommand struct {
+	BaseCommand
+	snapshotRepo *repository.SnapshotRepository
+}
+
+func NewHistoryCommand(cfg *config.Config, snapshotRepo *repository.SnapshotRepository) *HistoryCommand {
+	return &HistoryCommand{
+		BaseCommand:  BaseCommand{cfg: cfg},
+		snapshotRepo: snapshotRepo,
+	}
+}
+
+func (c *HistoryCommand) Metadata() Metadata {
+	return Metadata{
+		Name:        "history",
+		Description: "Chart score and ratings over time",
+		Args:        []Arg{storeArg, appIDArg, countryArg, {Name: "days", Type: ArgInt, Default: "30"}},
+		Example:     "/history google com.example.app vn 90",
+		Permission:  PermissionAdmin,
+	}
+}
+
+func (c *HistoryCommand) Execute(req *Request) Reply {
+	store := req.Args.String("store")
+	appID := req.Args.String("appId")
+	country := req.Args.String("country")
+
+	days := req.Args.Int("days")
+	if days <= 0 || days > maxHistoryDays {
+		return Reply{Text: fmt.Sprintf("Days must be between 1 and %d.", maxHistoryDays)}
+	}
+
+	snapshots, err := c.snapshotRepo.GetHistory(store, appID, country, time.Now().AddDate(0, 0, -int(days)))
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to get history: %v", err)}
+	}
+	if len(snapshots) < 2 {
+		return Reply{Text: fmt.Sprintf("Not enough history for %s (%s) yet. Snapshots are taken daily.", util.EscapeMarkdown(appID), country)}
+	}
+
+	photo, err := chart.History(snapshots)
+	if err != nil {
+		c.cfg.Logger.Error("Failed to render history chart", zap.String("appId", appID), zap.Error(err))
+	}
+
+	first, last := snapshots[0], snapshots[len(snapshots)-1]
+	return Reply{
+		Text: fmt.Sprintf("%s\nStore: %s\nCountry: %s\nPeriod: %s to %s (%d snapshots)\nScore: %.2f -> %.2f (%+.2f)\nRatings: %s -> %s (%+d)",
+			util.Bold(last.Title), store, country, first.Date, last.Date, len(snapshots),
+			first.Score, last.Score, last.Score-first.Score,
+			util.FormatNumber(first.Ratings), util.FormatNumber(last.Ratings), last.Ratings-first.Ratings),
+		Photo: photo,
+	}
+}
@@ -0,0 +1,55 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+)
+
+type LabelCommand struct {
+	BaseCommand
+	groupRepo *repository.GroupRepository
+}
+
+func NewLabelCommand(cfg *config.Config, groupRepo *repository.GroupRepository) *LabelCommand {
+	return &LabelCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		groupRepo:   groupRepo,
+	}
+}
+
+func (c *LabelCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "label",
+		Description:  "Set the benchmark label of an app, e.g. own or competitor. Omit the label to clear it",
+		Args:         []Arg{storeArg, appIDArg, {Name: "label", Type: ArgString}},
+		Example:      "/label google com.example.app competitor",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+		Mutating:     true,
+	}
+}
+
+func (c *LabelCommand) Execute(req *Request) Reply {
+	store := req.Args.String("store")
+	appID := req.Args.String("appId")
+	label := strings.ToLower(req.Args.String("label"))
+
+	var err error
+	if store == "apple" {
+		err = c.groupRepo.SetAppleAppLabel(req.ChatID(), appID, label)
+	} else {
+		err = c.groupRepo.SetGoogleAppLabel(req.ChatID(), appID, label)
+	}
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to set label: %v", err)}
+	}
+
+	if label == "" {
+		return Reply{Text: fmt.Sprintf("Label of %s has been cleared.", util.EscapeMarkdown(appID))}
+	}
+	return Reply{Text: fmt.Sprintf("%s has been labeled as %s.", util.EscapeMarkdown(appID), util.EscapeMarkdown(label))}
+}
@@ -0,0 +1,64 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+)
+
+type ListAdminsCommand struct {
+	BaseCommand
+	adminRepo *repository.AdminRepository
+}
+
+func NewListAdminsCommand(cfg *config.Config, adminRepo *repository.AdminRepository) *ListAdminsCommand {
+	return &ListAdminsCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		adminRepo:   adminRepo,
+	}
+}
+
+func (c *ListAdminsCommand) Metadata() Metadata {
+	return Metadata{
+		Name:        "listadmins",
+		Description: "List admins",
+		Permission:  PermissionCreator,
+	}
+}
+
+func (c *ListAdminsCommand) Execute(req *Request) Reply {
+	admins, err := c.adminRepo.GetAllAdmins()
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to get admins: %v", err)}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("*Configured admins (ADMIN_IDS):*\n")
+	i := 1
+	for _, userID := range c.cfg.GetAdminIDs() {
+		if !c.cfg.IsEnvAdmin(userID) {
+			continue
+		}
+		suffix := ""
+		if c.cfg.IsCreator(userID) {
+			suffix = " (creator)"
+		}
+		sb.WriteString(fmt.Sprintf("%d. %d%s\n", i, userID, suffix))
+		i++
+	}
+
+	if len(admins) > 0 {
+		sb.WriteString("\n*Runtime admins:*\n")
+		for i, admin := range admins {
+			sb.WriteString(fmt.Sprintf("%d. %d (added by %d on %s)\n",
+				i+1,
+				admin.UserID,
+				admin.AddedBy,
+				admin.AddedAt.In(c.cfg.VietnamLocation).Format("2006-01-02")))
+		}
+	}
+
+	return Reply{Text: sb.String()}
+}
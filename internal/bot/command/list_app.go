@@ -3,71 +3,448 @@ package command
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/miti99/store-scraper-bot-go/internal/api"
+	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
+	"github.com/miti99/store-scraper-bot-go/internal/api/google"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
 	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+)
+
+// Callback actions of the /listapp keyboard
+const (
+	listAppDelete        = "d"
+	listAppConfirmDelete = "D"
+	listAppCancel        = "c"
+	listAppRaw           = "r"
+	listAppScore         = "s"
+	listAppMute          = "m"
+	listAppPage          = "p"
+)
+
+const (
+	// listAppsPerPage keeps the keyboard well below Telegram's button limit
+	listAppsPerPage = 10
+	// listPageLength leaves room below the message limit for Markdown
+	listPageLength = 3500
+	// listFilterPrefix starts the header of a filtered list
+	listFilterPrefix = "Apps tagged"
+)
+
+const (
+	storeApple  = "a"
+	storeGoogle = "g"
 )
 
 type ListAppCommand struct {
 	BaseCommand
-	adminRepo *repository.AdminRepository
-	groupRepo *repository.GroupRepository
+	adminRepo     *repository.AdminRepository
+	groupRepo     *repository.GroupRepository
+	appleScraper  *apple.AppleScraper
+	googleScraper *google.GoogleScraper
 }
 
-func NewListAppCommand(cfg *config.Config, adminRepo *repository.AdminRepository, groupRepo *repository.GroupRepository) *ListAppCommand {
+func NewListAppCommand(
+	cfg *config.Config,
+	adminRepo *repository.AdminRepository,
+	groupRepo *repository.GroupRepository,
+	appleScraper *apple.AppleScraper,
+	googleScraper *google.GoogleScraper,
+) *ListAppCommand {
 	return &ListAppCommand{
-		BaseCommand: BaseCommand{cfg: cfg},
-		adminRepo:   adminRepo,
-		groupRepo:   groupRepo,
+		BaseCommand:   BaseCommand{cfg: cfg},
+		adminRepo:     adminRepo,
+		groupRepo:     groupRepo,
+		appleScraper:  appleScraper,
+		googleScraper: googleScraper,
+	}
+}
+
+func (c *ListAppCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "listapp",
+		Description:  "List apps in current group, optionally only those with the given tags",
+		Args:         []Arg{tagFilterArg},
+		Example:      "/listapp platform:ios",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
 	}
 }
 
-func (c *ListAppCommand) Execute(message *tgbotapi.Message) string {
-	if !c.requireAdmin(message) {
-		return "You are not authorized to use this command."
+func (c *ListAppCommand) Execute(req *Request) Reply {
+	group, err := c.getGroup(req.ChatID())
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to get group: %v", err)}
+	}
+
+	text, keyboard := c.render(group, parseTags(req.Args.String("tags")), 0)
+	return Reply{Text: text, Keyboard: keyboard}
+}
+
+// HandleCallback handles the list buttons. Their data carries the page the
+// list was on; the tag filter is read back from the message header.
+func (c *ListAppCommand) HandleCallback(req *CallbackRequest) CallbackReply {
+	fields := req.Fields()
+	if len(fields) < 2 {
+		return CallbackReply{Notice: "Invalid button."}
 	}
+	action := fields[0]
+	page, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return CallbackReply{Notice: "This list is outdated, run /listapp again."}
+	}
+	tags := listFilter(req.Query.Message)
 
-	groupID := message.Chat.ID
-	hasGroup, err := c.adminRepo.HasGroup(groupID)
+	group, err := c.getGroup(req.ChatID())
 	if err != nil {
-		return fmt.Sprintf("Failed to check group: %v", err)
+		return CallbackReply{Notice: fmt.Sprintf("Failed to get group: %v", err)}
+	}
+
+	if action == listAppCancel || action == listAppPage {
+		text, keyboard := c.render(group, tags, page)
+		return CallbackReply{Text: text, Keyboard: keyboard}
+	}
+
+	if len(fields) != 4 {
+		return CallbackReply{Notice: "Invalid button."}
+	}
+	store := fields[2]
+	appInfo, ok := findApp(group, store, fields[3])
+	if !ok {
+		text, keyboard := c.render(group, tags, page)
+		return CallbackReply{Notice: "App not found, the list was outdated.", Text: text, Keyboard: keyboard}
 	}
-	if !hasGroup {
-		return "This group is not registered."
+
+	switch action {
+	case listAppDelete:
+		return c.confirmDelete(tags, page, store, appInfo)
+	case listAppConfirmDelete:
+		return c.delete(req.ChatID(), tags, page, store, appInfo)
+	case listAppRaw:
+		return CallbackReply{Message: c.raw(store, appInfo)}
+	case listAppScore:
+		return CallbackReply{Message: c.score(store, appInfo)}
+	case listAppMute:
+		return c.toggleMute(req.ChatID(), tags, page, store, appInfo)
 	}
 
+	return CallbackReply{Notice: "Invalid button."}
+}
+
+func (c *ListAppCommand) getGroup(groupID int64) (*model.Group, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	group, err := c.groupRepo.Get(ctx, groupID)
-	if err != nil {
-		return fmt.Sprintf("Failed to get group: %v", err)
+	return c.groupRepo.Get(ctx, groupID)
+}
+
+// listEntry is a line of the list; app entries get a row of buttons.
+type listEntry struct {
+	section string
+	line    string
+	store   string
+	app     *model.AppInfo
+	n       int
+}
+
+// render lists the apps having all the given tags, one page at a time. A
+// page holds at most listAppsPerPage apps and listPageLength characters, so
+// it fits in a single message and edits of it do not fail.
+func (c *ListAppCommand) render(group *model.Group, tags []string, page int) (string, *tgbotapi.InlineKeyboardMarkup) {
+	if len(group.AppleApps) == 0 && len(group.GoogleApps) == 0 {
+		return "No apps in this group.", nil
+	}
+
+	appleApps := filterApps(group.AppleApps, tags)
+	googleApps := filterApps(group.GoogleApps, tags)
+	if len(appleApps) == 0 && len(googleApps) == 0 {
+		return fmt.Sprintf("No apps tagged %s in this group.", util.EscapeMarkdown(strings.Join(tags, " "))), nil
+	}
+
+	header := listHeader(tags)
+
+	var entries []listEntry
+	n := 0
+	addApps := func(section, store string, apps []model.AppInfo) {
+		for i := range apps {
+			app := &apps[i]
+			n++
+			extra := ""
+			if app.Label != "" {
+				extra += " {" + app.Label + "}"
+			}
+			if len(app.Tags) > 0 {
+				extra += " #" + strings.Join(app.Tags, " #")
+			}
+			if app.Muted {
+				extra += " [muted]"
+			}
+			entries = append(entries, listEntry{
+				section: section,
+				line:    fmt.Sprintf("%d. %s (%s)%s", n, util.EscapeMarkdown(app.AppID), strings.Join(app.Storefronts(), ", "), util.EscapeMarkdown(extra)),
+				store:   store,
+				app:     app,
+				n:       n,
+			})
+		}
+	}
+	addApps("Apple Apps", storeApple, appleApps)
+	addApps("Google Apps", storeGoogle, googleApps)
+
+	addDevelopers := func(section string, developers []model.DeveloperInfo) {
+		for i, developer := range developers {
+			entries = append(entries, listEntry{
+				section: section,
+				line: fmt.Sprintf("%d. %s - %s (%s), %d apps",
+					i+1, util.EscapeMarkdown(developer.DeveloperID), util.EscapeMarkdown(developer.Name), developer.Country, len(developer.AppIDs)),
+			})
+		}
 	}
+	addDevelopers("Apple Developers", group.AppleDevelopers)
+	addDevelopers("Google Developers", group.GoogleDevelopers)
+
+	pages := paginateList(entries, len(header))
+	page = max(0, min(page, len(pages)-1))
 
 	var sb strings.Builder
-	sb.WriteString("*Apps in this group:*\n\n")
+	var rows [][]tgbotapi.InlineKeyboardButton
+	sb.WriteString(header)
+	section := ""
+	for _, entry := range pages[page] {
+		if entry.section != section {
+			if section != "" {
+				sb.WriteString("\n")
+			}
+			section = entry.section
+			sb.WriteString(fmt.Sprintf("*%s:*\n", section))
+		}
+		sb.WriteString(entry.line + "\n")
+		if entry.app != nil {
+			rows = append(rows, c.appButtons(entry.n, page, entry.store, *entry.app))
+		}
+	}
+
+	if len(pages) > 1 {
+		sb.WriteString(fmt.Sprintf("\nPage %d of %d\n", page+1, len(pages)))
 
-	if len(group.AppleApps) > 0 {
-		sb.WriteString("*Apple Apps:*\n")
-		for i, app := range group.AppleApps {
-			sb.WriteString(fmt.Sprintf("%d. %s (%s)\n", i+1, app.AppID, app.Country))
+		var nav []tgbotapi.InlineKeyboardButton
+		if page > 0 {
+			nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("« Prev", callbackData("listapp", listAppPage, strconv.Itoa(page-1))))
 		}
-		sb.WriteString("\n")
+		if page < len(pages)-1 {
+			nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("Next »", callbackData("listapp", listAppPage, strconv.Itoa(page+1))))
+		}
+		rows = append(rows, nav)
 	}
 
-	if len(group.GoogleApps) > 0 {
-		sb.WriteString("*Google Apps:*\n")
-		for i, app := range group.GoogleApps {
-			sb.WriteString(fmt.Sprintf("%d. %s (%s)\n", i+1, app.AppID, app.Country))
+	text := strings.TrimSuffix(sb.String(), "\n")
+	if len(rows) == 0 {
+		return text, nil
+	}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return text, &keyboard
+}
+
+// paginateList packs the entries into pages, starting a new page when the
+// next entry would exceed either page limit.
+func paginateList(entries []listEntry, headerLength int) [][]listEntry {
+	var pages [][]listEntry
+	var current []listEntry
+	length, apps := headerLength, 0
+	section := ""
+
+	for _, entry := range entries {
+		// A section starts with its title and a blank line before it
+		lineLength, sectionLength := len(entry.line)+1, len(entry.section)+5
+		entryLength := lineLength
+		if entry.section != section || len(current) == 0 {
+			entryLength += sectionLength
+		}
+
+		full := length+entryLength > listPageLength || (entry.app != nil && apps >= listAppsPerPage)
+		if full && len(current) > 0 {
+			pages = append(pages, current)
+			current = nil
+			length, apps = headerLength, 0
+			entryLength = lineLength + sectionLength
+		}
+
+		current = append(current, entry)
+		length += entryLength
+		section = entry.section
+		if entry.app != nil {
+			apps++
 		}
 	}
+	return append(pages, current)
+}
 
-	if len(group.AppleApps) == 0 && len(group.GoogleApps) == 0 {
-		return "No apps in this group."
+// listHeader starts every list message. Buttons cannot carry the tag filter
+// within the callback data limit, so it is parsed back by listFilter.
+func listHeader(tags []string) string {
+	if len(tags) > 0 {
+		return fmt.Sprintf("*%s* %s:\n\n", listFilterPrefix, util.EscapeMarkdown(strings.Join(tags, " ")))
 	}
+	return "*Apps in this group:*\n\n"
+}
+
+// listFilter returns the tag filter of a list message, as shown in the plain
+// text of its header.
+func listFilter(message *tgbotapi.Message) []string {
+	if message == nil {
+		return nil
+	}
+	line, _, _ := strings.Cut(message.Text, "\n")
+	filter, ok := strings.CutPrefix(line, listFilterPrefix+" ")
+	if !ok {
+		return nil
+	}
+	return parseTags(strings.TrimSuffix(filter, ":"))
+}
+
+func (c *ListAppCommand) appButtons(n, page int, store string, app model.AppInfo) []tgbotapi.InlineKeyboardButton {
+	key := appKey(app.AppID)
+	pageKey := strconv.Itoa(page)
+	muteLabel := "Mute"
+	if app.Muted {
+		muteLabel = "Unmute"
+	}
+
+	return tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d: Delete", n), callbackData("listapp", listAppDelete, pageKey, store, key)),
+		tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d: Raw", n), callbackData("listapp", listAppRaw, pageKey, store, key)),
+		tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d: Score", n), callbackData("listapp", listAppScore, pageKey, store, key)),
+		tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d: %s", n, muteLabel), callbackData("listapp", listAppMute, pageKey, store, key)),
+	)
+}
+
+// confirmDelete keeps the list header, so cancelling restores the filter.
+func (c *ListAppCommand) confirmDelete(tags []string, page int, store string, app model.AppInfo) CallbackReply {
+	key := appKey(app.AppID)
+	pageKey := strconv.Itoa(page)
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("Yes, delete", callbackData("listapp", listAppConfirmDelete, pageKey, store, key)),
+		tgbotapi.NewInlineKeyboardButtonData("Cancel", callbackData("listapp", listAppCancel, pageKey)),
+	))
+
+	return CallbackReply{
+		Text: listHeader(tags) + fmt.Sprintf("Delete %s app %s (%s) from this group?",
+			storeName(store), util.Bold(app.AppID), strings.Join(app.Storefronts(), ", ")),
+		Keyboard: &keyboard,
+	}
+}
+
+func (c *ListAppCommand) delete(groupID int64, tags []string, page int, store string, app model.AppInfo) CallbackReply {
+	var err error
+	if store == storeApple {
+		err = c.groupRepo.RemoveAppleApp(groupID, app.AppID)
+	} else {
+		err = c.groupRepo.RemoveGoogleApp(groupID, app.AppID)
+	}
+	if err != nil {
+		return CallbackReply{Notice: fmt.Sprintf("Failed to remove app: %v", err)}
+	}
+
+	return c.refresh(groupID, tags, page, fmt.Sprintf("%s app %s has been removed.", storeName(store), app.AppID),
+		fmt.Sprintf("delete %s %s", storeName(store), app.AppID))
+}
+
+func (c *ListAppCommand) toggleMute(groupID int64, tags []string, page int, store string, app model.AppInfo) CallbackReply {
+	muted := !app.Muted
+
+	var err error
+	if store == storeApple {
+		err = c.groupRepo.SetAppleAppMuted(groupID, app.AppID, muted)
+	} else {
+		err = c.groupRepo.SetGoogleAppMuted(groupID, app.AppID, muted)
+	}
+	if err != nil {
+		return CallbackReply{Notice: fmt.Sprintf("Failed to update app: %v", err)}
+	}
+
+	action := "unmute"
+	if muted {
+		action = "mute"
+	}
+	return c.refresh(groupID, tags, page, fmt.Sprintf("%s: %sd", app.AppID, action),
+		fmt.Sprintf("%s %s %s", action, storeName(store), app.AppID))
+}
 
-	return sb.String()
+// refresh re-renders the list page after a change and records it for auditing.
+func (c *ListAppCommand) refresh(groupID int64, tags []string, page int, notice, auditArgs string) CallbackReply {
+	reply := CallbackReply{Notice: notice, AuditArgs: auditArgs}
+
+	group, err := c.getGroup(groupID)
+	if err != nil {
+		return reply
+	}
+
+	reply.Text, reply.Keyboard = c.render(group, tags, page)
+	return reply
+}
+
+func (c *ListAppCommand) raw(store string, app model.AppInfo) string {
+	if store == storeApple {
+		data, err := c.appleScraper.GetApp(app.AppID, app.Country)
+		if err != nil {
+			return fmt.Sprintf("Failed to fetch app: %s", api.Reason(err))
+		}
+		return formatRawJSON(data)
+	}
+
+	data, err := c.googleScraper.GetApp(app.AppID, app.Country)
+	if err != nil {
+		return fmt.Sprintf("Failed to fetch app: %s", api.Reason(err))
+	}
+	return formatRawJSON(data)
+}
+
+func (c *ListAppCommand) score(store string, app model.AppInfo) string {
+	var title string
+	var score float64
+	var reviews, ratings int64
+
+	if store == storeApple {
+		data, err := c.appleScraper.GetApp(app.AppID, app.Country)
+		if err != nil {
+			return fmt.Sprintf("Failed to fetch app: %s", api.Reason(err))
+		}
+		title, score, reviews, ratings = data.Title, data.Score, int64(data.Reviews), data.Ratings
+	} else {
+		data, err := c.googleScraper.GetApp(app.AppID, app.Country)
+		if err != nil {
+			return fmt.Sprintf("Failed to fetch app: %s", api.Reason(err))
+		}
+		title, score, reviews, ratings = data.Title, data.Score, data.Reviews, data.Ratings
+	}
+
+	return fmt.Sprintf("%s\nStore: %s\nCountry: %s\nScore: %.1f\nReviews: %d\nRatings: %s",
+		util.Bold(title), storeName(store), app.Country, score, reviews, util.FormatNumber(ratings))
+}
+
+func findApp(group *model.Group, store, key string) (model.AppInfo, bool) {
+	apps := group.GoogleApps
+	if store == storeApple {
+		apps = group.AppleApps
+	}
+
+	for _, app := range apps {
+		if appKey(app.AppID) == key {
+			return app, true
+		}
+	}
+	return model.AppInfo{}, false
+}
+
+func storeName(store string) string {
+	if store == storeApple {
+		return "Apple"
+	}
+	return "Google"
 }
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
 	"github.com/miti99/store-scraper-bot-go/internal/repository"
 )
@@ -21,18 +20,22 @@ func NewListGroupCommand(cfg *config.Config, adminRepo *repository.AdminReposito
 	}
 }
 
-func (c *ListGroupCommand) Execute(message *tgbotapi.Message) string {
-	if !c.requireAdmin(message) {
-		return "You are not authorized to use this command."
+func (c *ListGroupCommand) Metadata() Metadata {
+	return Metadata{
+		Name:        "listgroup",
+		Description: "List all monitored groups",
+		Permission:  PermissionAdmin,
 	}
+}
 
+func (c *ListGroupCommand) Execute(req *Request) Reply {
 	groups, err := c.adminRepo.GetAllGroups()
 	if err != nil {
-		return fmt.Sprintf("Failed to get groups: %v", err)
+		return Reply{Text: fmt.Sprintf("Failed to get groups: %v", err)}
 	}
 
 	if len(groups) == 0 {
-		return "No groups found."
+		return Reply{Text: "No groups found."}
 	}
 
 	var sb strings.Builder
@@ -41,5 +44,5 @@ func (c *ListGroupCommand) Execute(message *tgbotapi.Message) string {
 		sb.WriteString(fmt.Sprintf("%d. %d\n", i+1, groupID))
 	}
 
-	return sb.String()
+	return Reply{Text: sb.String()}
 }
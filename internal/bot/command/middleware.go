@@ -0,0 +1,70 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+)
+
+type Handler func(req *Request) Reply
+
+// Middleware wraps a command handler. It receives the command metadata so it
+// can decide whether to act, e.g. only for mutating commands.
+type Middleware func(meta Metadata, next Handler) Handler
+
+func hasPermission(cfg *config.Config, userID int64, permission Permission) bool {
+	switch permission {
+	case PermissionCreator:
+		return cfg.IsCreator(userID)
+	case PermissionAdmin:
+		return cfg.IsAdmin(userID)
+	default:
+		return true
+	}
+}
+
+func RequirePermission(cfg *config.Config) Middleware {
+	return func(meta Metadata, next Handler) Handler {
+		return func(req *Request) Reply {
+			if hasPermission(cfg, req.UserID(), meta.Permission) {
+				return next(req)
+			}
+			if meta.Permission == PermissionCreator {
+				return Reply{Text: "Only the bot creator can use this command."}
+			}
+			return Reply{Text: "You are not authorized to use this command."}
+		}
+	}
+}
+
+func RequireGroup(adminRepo *repository.AdminRepository) Middleware {
+	return func(meta Metadata, next Handler) Handler {
+		if !meta.RequireGroup {
+			return next
+		}
+		return func(req *Request) Reply {
+			hasGroup, err := adminRepo.HasGroup(req.ChatID())
+			if err != nil {
+				return Reply{Text: fmt.Sprintf("Failed to check group: %v", err)}
+			}
+			if !hasGroup {
+				return Reply{Text: "This group is not registered. Please use /addgroup first."}
+			}
+			return next(req)
+		}
+	}
+}
+
+func ParseArgs() Middleware {
+	return func(meta Metadata, next Handler) Handler {
+		return func(req *Request) Reply {
+			args, err := parseArgs(meta.Args, req.Message.CommandArguments())
+			if err != nil {
+				return Reply{Text: fmt.Sprintf("Invalid arguments: %v\n%s", err, meta.UsageText())}
+			}
+			req.Args = args
+			return next(req)
+		}
+	}
+}
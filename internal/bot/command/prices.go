@@ -0,0 +1,165 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/api"
+	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
+	"github.com/miti99/store-scraper-bot-go/internal/api/google"
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// priceHistoryDays is how far back the last price change is looked up.
+const priceHistoryDays = 365
+
+// storefrontPrice is one country's price of an app and its last change.
+type storefrontPrice struct {
+	Country  string
+	Price    string
+	Previous string
+	Changed  string
+	Err      error
+}
+
+type PricesCommand struct {
+	BaseCommand
+	groupRepo     *repository.GroupRepository
+	snapshotRepo  *repository.SnapshotRepository
+	appleScraper  *apple.AppleScraper
+	googleScraper *google.GoogleScraper
+}
+
+func NewPricesCommand(
+	cfg *config.Config,
+	groupRepo *repository.GroupRepository,
+	snapshotRepo *repository.SnapshotRepository,
+	appleScraper *apple.AppleScraper,
+	googleScraper *google.GoogleScraper,
+) *PricesCommand {
+	return &PricesCommand{
+		BaseCommand:   BaseCommand{cfg: cfg},
+		groupRepo:     groupRepo,
+		snapshotRepo:  snapshotRepo,
+		appleScraper:  appleScraper,
+		googleScraper: googleScraper,
+	}
+}
+
+func (c *PricesCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "prices",
+		Description:  "Compare an app's price across its tracked countries",
+		Args:         []Arg{appIDArg},
+		Example:      "/prices com.example.app",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+	}
+}
+
+func (c *PricesCommand) Execute(req *Request) Reply {
+	appID := req.Args.String("appId")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := c.groupRepo.Get(ctx, req.ChatID())
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to get group: %v", err)}
+	}
+
+	appleCountries := storefrontsOf(group.AppleApps, appID)
+	googleCountries := storefrontsOf(group.GoogleApps, appID)
+	if len(appleCountries) == 0 && len(googleCountries) == 0 {
+		return Reply{Text: fmt.Sprintf("App %s is not tracked in this group.", util.EscapeMarkdown(appID))}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("*Price Comparison*\nApp: %s\n", util.EscapeMarkdown(appID)))
+
+	if len(appleCountries) > 0 {
+		prices := make([]storefrontPrice, 0, len(appleCountries))
+		for _, country := range appleCountries {
+			// Fetching records a snapshot, so the history ends at the current price
+			_, err := c.appleScraper.GetApp(appID, country)
+			prices = append(prices, c.storefrontPrice(model.StoreApple, appID, country, err))
+		}
+		sb.WriteString("\n*Apple:*\n")
+		sb.WriteString(buildPriceTable(prices))
+		sb.WriteString("\n")
+	}
+
+	if len(googleCountries) > 0 {
+		prices := make([]storefrontPrice, 0, len(googleCountries))
+		for _, country := range googleCountries {
+			_, err := c.googleScraper.GetApp(appID, country)
+			prices = append(prices, c.storefrontPrice(model.StoreGoogle, appID, country, err))
+		}
+		sb.WriteString("\n*Google:*\n")
+		sb.WriteString(buildPriceTable(prices))
+		sb.WriteString("\n")
+	}
+
+	return Reply{Text: sb.String()}
+}
+
+// storefrontPrice reads the current price and its last change from the
+// snapshot history.
+func (c *PricesCommand) storefrontPrice(store, appID, country string, fetchErr error) storefrontPrice {
+	sp := storefrontPrice{Country: country, Err: fetchErr}
+	if fetchErr != nil {
+		return sp
+	}
+
+	snapshots, err := c.snapshotRepo.GetHistory(store, appID, country, time.Now().AddDate(0, 0, -priceHistoryDays))
+	if err != nil {
+		c.cfg.Logger.Error("Failed to get snapshots",
+			zap.String("appId", appID),
+			zap.String("country", country),
+			zap.Error(err))
+		sp.Err = err
+		return sp
+	}
+
+	sp.Price, sp.Previous, sp.Changed = lastPriceChange(snapshots)
+	return sp
+}
+
+// lastPriceChange walks the snapshots oldest first and returns the latest
+// price, the one before it and the date it changed. Snapshots without a
+// recorded listing are skipped.
+func lastPriceChange(snapshots []model.AppSnapshot) (current, previous, changed string) {
+	current, previous, changed = "-", "-", "-"
+	for i := range snapshots {
+		s := &snapshots[i]
+		if !s.HasListing() {
+			continue
+		}
+		price := s.PriceText()
+		if current != "-" && price != current {
+			previous, changed = current, s.Date
+		}
+		current = price
+	}
+	return current, previous, changed
+}
+
+func buildPriceTable(prices []storefrontPrice) string {
+	var rows [][]string
+	for _, sp := range prices {
+		if sp.Err != nil {
+			rows = append(rows, []string{sp.Country, "-", "-", api.Label(sp.Err)})
+			continue
+		}
+		rows = append(rows, []string{sp.Country, sp.Price, sp.Previous, sp.Changed})
+	}
+
+	headers := []string{"Country", "Price", "Previous", "Changed"}
+	return util.BuildTable(headers, rows)
+}
@@ -0,0 +1,106 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+	"go.uber.org/zap"
+)
+
+type RankCommand struct {
+	BaseCommand
+	groupRepo   *repository.GroupRepository
+	rankingRepo *repository.RankingRepository
+}
+
+func NewRankCommand(
+	cfg *config.Config,
+	groupRepo *repository.GroupRepository,
+	rankingRepo *repository.RankingRepository,
+) *RankCommand {
+	return &RankCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		groupRepo:   groupRepo,
+		rankingRepo: rankingRepo,
+	}
+}
+
+func (c *RankCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "rank",
+		Description:  "Show an app's positions in the tracked top charts",
+		Args:         []Arg{appIDArg},
+		Example:      "/rank com.example.app",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+	}
+}
+
+func (c *RankCommand) Execute(req *Request) Reply {
+	appID := req.Args.String("appId")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := c.groupRepo.Get(ctx, req.ChatID())
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to get group: %v", err)}
+	}
+
+	tracked := map[string]bool{
+		model.StoreApple:  len(storefrontsOf(group.AppleApps, appID)) > 0,
+		model.StoreGoogle: len(storefrontsOf(group.GoogleApps, appID)) > 0,
+	}
+	if !tracked[model.StoreApple] && !tracked[model.StoreGoogle] {
+		return Reply{Text: fmt.Sprintf("App %s is not tracked in this group.", util.EscapeMarkdown(appID))}
+	}
+
+	var rows [][]string
+	for _, chart := range group.Charts {
+		if !tracked[chart.Store] {
+			continue
+		}
+
+		rankings, err := c.rankingRepo.GetRecent(chart, 2)
+		if err != nil {
+			c.cfg.Logger.Error("Failed to get chart rankings",
+				zap.String("chart", chart.Key()),
+				zap.Error(err))
+			continue
+		}
+
+		row := []string{chart.Name(), storeName(chart.Store), chart.Country, "-", "-", "-"}
+		if len(rankings) > 0 {
+			row[3] = formatPosition(&rankings[0], appID)
+			row[5] = rankings[0].Date
+		}
+		if len(rankings) > 1 {
+			row[4] = formatPosition(&rankings[1], appID)
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return Reply{Text: "No charts tracked for this app's store. Use /addchart to add one."}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("*Chart Positions*\nApp: %s\n\n", util.EscapeMarkdown(appID)))
+	sb.WriteString(util.BuildTable([]string{"Chart", "Store", "Country", "Rank", "Prev", "Date"}, rows))
+	return Reply{Text: sb.String()}
+}
+
+// formatPosition renders the app's position, e.g. "#7", or ">100" when it is
+// not among the captured entries.
+func formatPosition(ranking *model.ChartRanking, appID string) string {
+	if position := ranking.Position(appID); position > 0 {
+		return fmt.Sprintf("#%d", position)
+	}
+	return fmt.Sprintf(">%d", len(ranking.Entries))
+}
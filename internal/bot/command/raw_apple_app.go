@@ -3,9 +3,8 @@ package command
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/miti99/store-scraper-bot-go/internal/api"
 	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
 )
@@ -22,37 +21,31 @@ func NewRawAppleAppCommand(cfg *config.Config, appleScraper *apple.AppleScraper)
 	}
 }
 
-func (c *RawAppleAppCommand) Execute(message *tgbotapi.Message) string {
-	if !c.requireAdmin(message) {
-		return "You are not authorized to use this command."
-	}
-
-	args := strings.Fields(message.CommandArguments())
-	if len(args) == 0 {
-		return "Usage: /rawapple <appId> [country]\nExample: /rawapple com.example.app vn"
-	}
-
-	appID := args[0]
-	country := "vn"
-	if len(args) > 1 {
-		country = args[1]
+func (c *RawAppleAppCommand) Metadata() Metadata {
+	return Metadata{
+		Name:        "rawapple",
+		Description: "Get raw Apple data",
+		Args:        []Arg{appIDArg, countryArg},
+		Example:     "/rawapple com.example.app vn",
+		Permission:  PermissionAdmin,
 	}
+}
 
-	app, err := c.appleScraper.GetApp(appID, country)
+func (c *RawAppleAppCommand) Execute(req *Request) Reply {
+	app, err := c.appleScraper.GetApp(req.Args.String("appId"), req.Args.String("country"))
 	if err != nil {
-		return fmt.Sprintf("Failed to fetch app: %v", err)
+		return Reply{Text: fmt.Sprintf("Failed to fetch app: %s", api.Reason(err))}
 	}
 
-	jsonData, err := json.MarshalIndent(app, "", "  ")
+	return Reply{Text: formatRawJSON(app)}
+}
+
+func formatRawJSON(v any) string {
+	jsonData, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
 		return fmt.Sprintf("Failed to marshal JSON: %v", err)
 	}
 
-	// Telegram has a message size limit, so we might need to truncate
-	jsonStr := string(jsonData)
-	if len(jsonStr) > 4000 {
-		jsonStr = jsonStr[:4000] + "\n...(truncated)"
-	}
-
-	return fmt.Sprintf("```json\n%s\n```", jsonStr)
+	// Long output is split into several messages by the bot
+	return fmt.Sprintf("```json\n%s\n```", jsonData)
 }
@@ -1,11 +1,9 @@
 package command
 
 import (
-	"encoding/json"
 	"fmt"
-	"strings"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/miti99/store-scraper-bot-go/internal/api"
 	"github.com/miti99/store-scraper-bot-go/internal/api/google"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
 )
@@ -22,37 +20,21 @@ func NewRawGoogleAppCommand(cfg *config.Config, googleScraper *google.GoogleScra
 	}
 }
 
-func (c *RawGoogleAppCommand) Execute(message *tgbotapi.Message) string {
-	if !c.requireAdmin(message) {
-		return "You are not authorized to use this command."
-	}
-
-	args := strings.Fields(message.CommandArguments())
-	if len(args) == 0 {
-		return "Usage: /rawgoogle <appId> [country]\nExample: /rawgoogle com.example.app vn"
-	}
-
-	appID := args[0]
-	country := "vn"
-	if len(args) > 1 {
-		country = args[1]
-	}
-
-	app, err := c.googleScraper.GetApp(appID, country)
-	if err != nil {
-		return fmt.Sprintf("Failed to fetch app: %v", err)
+func (c *RawGoogleAppCommand) Metadata() Metadata {
+	return Metadata{
+		Name:        "rawgoogle",
+		Description: "Get raw Google data",
+		Args:        []Arg{appIDArg, countryArg},
+		Example:     "/rawgoogle com.example.app vn",
+		Permission:  PermissionAdmin,
 	}
+}
 
-	jsonData, err := json.MarshalIndent(app, "", "  ")
+func (c *RawGoogleAppCommand) Execute(req *Request) Reply {
+	app, err := c.googleScraper.GetApp(req.Args.String("appId"), req.Args.String("country"))
 	if err != nil {
-		return fmt.Sprintf("Failed to marshal JSON: %v", err)
-	}
-
-	// Telegram has a message size limit, so we might need to truncate
-	jsonStr := string(jsonData)
-	if len(jsonStr) > 4000 {
-		jsonStr = jsonStr[:4000] + "\n...(truncated)"
+		return Reply{Text: fmt.Sprintf("Failed to fetch app: %s", api.Reason(err))}
 	}
 
-	return fmt.Sprintf("```json\n%s\n```", jsonStr)
+	return Reply{Text: formatRawJSON(app)}
 }
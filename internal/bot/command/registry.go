@@ -0,0 +1,143 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+)
+
+// Registry holds commands in registration order and dispatches messages
+// through the middleware chain.
+type Registry struct {
+	cfg         *config.Config
+	adminRepo   *repository.AdminRepository
+	commands    map[string]Command
+	order       []Command
+	middlewares []Middleware
+	builtins    []Middleware
+}
+
+func NewRegistry(cfg *config.Config, adminRepo *repository.AdminRepository) *Registry {
+	return &Registry{
+		cfg:       cfg,
+		adminRepo: adminRepo,
+		commands:  make(map[string]Command),
+		builtins: []Middleware{
+			RequirePermission(cfg),
+			RequireGroup(adminRepo),
+			ParseArgs(),
+		},
+	}
+}
+
+// Use adds middlewares that run before the built-in permission, group and
+// argument checks, so they also see rejected invocations.
+func (r *Registry) Use(middlewares ...Middleware) {
+	r.middlewares = append(r.middlewares, middlewares...)
+}
+
+func (r *Registry) Register(commands ...Command) {
+	for _, cmd := range commands {
+		name := cmd.Metadata().Name
+		if _, exists := r.commands[name]; !exists {
+			r.order = append(r.order, cmd)
+		}
+		r.commands[name] = cmd
+	}
+}
+
+func (r *Registry) Get(name string) (Command, bool) {
+	cmd, ok := r.commands[name]
+	return cmd, ok
+}
+
+// Commands returns all commands in registration order.
+func (r *Registry) Commands() []Command {
+	return r.order
+}
+
+// CommandsFor returns the commands the user is allowed to run.
+func (r *Registry) CommandsFor(userID int64) []Command {
+	commands := make([]Command, 0, len(r.order))
+	for _, cmd := range r.order {
+		if hasPermission(r.cfg, userID, cmd.Metadata().Permission) {
+			commands = append(commands, cmd)
+		}
+	}
+	return commands
+}
+
+// Menu returns the Telegram menu entries for a role in a chat type. A role
+// sees every command up to its own permission level.
+func (r *Registry) Menu(permission Permission, chat ChatType) []tgbotapi.BotCommand {
+	menu := make([]tgbotapi.BotCommand, 0, len(r.order))
+	for _, cmd := range r.order {
+		meta := cmd.Metadata()
+		if meta.Permission > permission || !meta.AvailableIn(chat) {
+			continue
+		}
+		menu = append(menu, tgbotapi.BotCommand{
+			Command:     meta.Name,
+			Description: meta.Description,
+		})
+	}
+	return menu
+}
+
+// Handle runs the named command for the message. It reports false when the
+// command is unknown.
+func (r *Registry) Handle(name string, message *tgbotapi.Message) (Reply, bool) {
+	cmd, ok := r.commands[name]
+	if !ok {
+		return Reply{}, false
+	}
+
+	meta := cmd.Metadata()
+	handler := Handler(cmd.Execute)
+
+	chain := append(append([]Middleware{}, r.middlewares...), r.builtins...)
+	for i := len(chain) - 1; i >= 0; i-- {
+		handler = chain[i](meta, handler)
+	}
+
+	return handler(&Request{Message: message}), true
+}
+
+// HandleCallback routes an inline keyboard press to the command named in the
+// callback data. The presser must have the command's permission, and group
+// commands still require a registered group.
+func (r *Registry) HandleCallback(query *tgbotapi.CallbackQuery) (CallbackReply, bool) {
+	if query.Message == nil {
+		return CallbackReply{}, false
+	}
+
+	name, payload, _ := strings.Cut(query.Data, callbackSeparator)
+	cmd, ok := r.commands[name]
+	if !ok {
+		return CallbackReply{}, false
+	}
+	handler, ok := cmd.(CallbackHandler)
+	if !ok {
+		return CallbackReply{}, false
+	}
+
+	meta := cmd.Metadata()
+	if !hasPermission(r.cfg, query.From.ID, meta.Permission) {
+		return CallbackReply{Notice: "You are not authorized to use this command."}, true
+	}
+
+	if meta.RequireGroup {
+		hasGroup, err := r.adminRepo.HasGroup(query.Message.Chat.ID)
+		if err != nil {
+			return CallbackReply{Notice: fmt.Sprintf("Failed to check group: %v", err)}, true
+		}
+		if !hasGroup {
+			return CallbackReply{Notice: "This group is not registered."}, true
+		}
+	}
+
+	return handler.HandleCallback(&CallbackRequest{Query: query, Payload: payload}), true
+}
@@ -0,0 +1,57 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"go.uber.org/zap"
+)
+
+type RemoveAdminCommand struct {
+	BaseCommand
+	adminRepo *repository.AdminRepository
+	menus     MenuPublisher
+}
+
+func NewRemoveAdminCommand(cfg *config.Config, adminRepo *repository.AdminRepository, menus MenuPublisher) *RemoveAdminCommand {
+	return &RemoveAdminCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		adminRepo:   adminRepo,
+		menus:       menus,
+	}
+}
+
+func (c *RemoveAdminCommand) Metadata() Metadata {
+	return Metadata{
+		Name:        "removeadmin",
+		Description: "Remove admin, or reply to a user's message",
+		Args:        []Arg{userIDArg},
+		Example:     "/removeadmin 123456789",
+		Permission:  PermissionCreator,
+		Mutating:    true,
+	}
+}
+
+func (c *RemoveAdminCommand) Execute(req *Request) Reply {
+	userID, ok := targetUserID(req)
+	if !ok {
+		return Reply{Text: c.Metadata().UsageText()}
+	}
+
+	if c.cfg.IsEnvAdmin(userID) {
+		return Reply{Text: fmt.Sprintf("User %d is configured in `ADMIN_IDS` and cannot be removed at runtime.", userID)}
+	}
+
+	if err := c.adminRepo.RemoveAdmin(userID); err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to remove admin: %v", err)}
+	}
+	c.cfg.RemoveAdminID(userID)
+	c.menus.ClearAdminMenu(userID)
+
+	c.cfg.Logger.Info("Admin removed",
+		zap.Int64("userId", userID),
+		zap.Int64("removedBy", req.UserID()))
+
+	return Reply{Text: fmt.Sprintf("User %d has been removed from admins.", userID)}
+}
@@ -0,0 +1,140 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+)
+
+const minKeywordLength = 2
+
+var keywordArg = Arg{Name: "keyword", Type: ArgText, Required: true}
+
+type AddRuleCommand struct {
+	BaseCommand
+	groupRepo *repository.GroupRepository
+}
+
+func NewAddRuleCommand(cfg *config.Config, groupRepo *repository.GroupRepository) *AddRuleCommand {
+	return &AddRuleCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		groupRepo:   groupRepo,
+	}
+}
+
+func (c *AddRuleCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "addrule",
+		Description:  "Alert when a new review mentions a keyword or phrase",
+		Args:         []Arg{keywordArg},
+		Example:      "/addrule crash",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+		Mutating:     true,
+	}
+}
+
+func (c *AddRuleCommand) Execute(req *Request) Reply {
+	keyword := normalizeKeyword(req.Args.String("keyword"))
+	if len(keyword) < minKeywordLength {
+		return Reply{Text: fmt.Sprintf("Keyword must have at least %d characters.", minKeywordLength)}
+	}
+
+	if err := c.groupRepo.AddReviewRule(req.ChatID(), keyword, req.UserID()); err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to add rule: %v", err)}
+	}
+
+	return Reply{Text: fmt.Sprintf("Rule added. New reviews mentioning \"%s\" will trigger an alert.", util.EscapeMarkdown(keyword))}
+}
+
+type DeleteRuleCommand struct {
+	BaseCommand
+	groupRepo *repository.GroupRepository
+}
+
+func NewDeleteRuleCommand(cfg *config.Config, groupRepo *repository.GroupRepository) *DeleteRuleCommand {
+	return &DeleteRuleCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		groupRepo:   groupRepo,
+	}
+}
+
+func (c *DeleteRuleCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "deleterule",
+		Description:  "Remove a review keyword rule",
+		Args:         []Arg{keywordArg},
+		Example:      "/deleterule crash",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+		Mutating:     true,
+	}
+}
+
+func (c *DeleteRuleCommand) Execute(req *Request) Reply {
+	keyword := normalizeKeyword(req.Args.String("keyword"))
+
+	if err := c.groupRepo.RemoveReviewRule(req.ChatID(), keyword); err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to remove rule: %v", err)}
+	}
+
+	return Reply{Text: fmt.Sprintf("Rule \"%s\" has been removed.", util.EscapeMarkdown(keyword))}
+}
+
+type ListRulesCommand struct {
+	BaseCommand
+	groupRepo *repository.GroupRepository
+}
+
+func NewListRulesCommand(cfg *config.Config, groupRepo *repository.GroupRepository) *ListRulesCommand {
+	return &ListRulesCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		groupRepo:   groupRepo,
+	}
+}
+
+func (c *ListRulesCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "listrules",
+		Description:  "List review keyword rules",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+	}
+}
+
+func (c *ListRulesCommand) Execute(req *Request) Reply {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := c.groupRepo.Get(ctx, req.ChatID())
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to get group: %v", err)}
+	}
+
+	if len(group.ReviewRules) == 0 {
+		return Reply{Text: "No review rules in this group. Use /addrule to add one."}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("*Review keyword rules:*\n")
+	for i, rule := range group.ReviewRules {
+		sb.WriteString(fmt.Sprintf("%d. %s (added by %d on %s)\n",
+			i+1,
+			util.EscapeMarkdown(rule.Keyword),
+			rule.AddedBy,
+			rule.AddedAt.In(c.cfg.VietnamLocation).Format("2006-01-02")))
+	}
+
+	return Reply{Text: sb.String()}
+}
+
+// normalizeKeyword lowercases and collapses whitespace, so rules match
+// regardless of how they were typed.
+func normalizeKeyword(keyword string) string {
+	return strings.Join(strings.Fields(strings.ToLower(keyword)), " ")
+}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miti99/store-scraper-bot-go/internal/api"
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/review"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+)
+
+const maxReviews = 20
+
+type ReviewsCommand struct {
+	BaseCommand
+	reviewCollector *review.Collector
+}
+
+func NewReviewsCommand(cfg *config.Config, reviewCollector *review.Collector) *ReviewsCommand {
+	return &ReviewsCommand{
+		BaseCommand:     BaseCommand{cfg: cfg},
+		reviewCollector: reviewCollector,
+	}
+}
+
+func (c *ReviewsCommand) Metadata() Metadata {
+	return Metadata{
+		Name:        "reviews",
+		Description: "Show the most recent user reviews",
+		Args:        []Arg{storeArg, appIDArg, {Name: "n", Type: ArgInt, Default: "5"}, countryArg},
+		Example:     "/reviews google com.example.app 10 vn",
+		Permission:  PermissionAdmin,
+	}
+}
+
+func (c *ReviewsCommand) Execute(req *Request) Reply {
+	store := req.Args.String("store")
+	appID := req.Args.String("appId")
+	country := req.Args.String("country")
+
+	n := int(req.Args.Int("n"))
+	if n <= 0 || n > maxReviews {
+		return Reply{Text: fmt.Sprintf("Number of reviews must be between 1 and %d.", maxReviews)}
+	}
+
+	// Read from the store without saving, saving would mark the reviews as
+	// seen and swallow the scheduled alerts. The stored reviews still show
+	// when the store is unavailable.
+	reviews, fetchErr := c.reviewCollector.FetchRecent(store, appID, country, n)
+	if fetchErr != nil || len(reviews) == 0 {
+		var err error
+		reviews, err = c.reviewCollector.GetRecent(store, appID, country, n)
+		if err != nil {
+			return Reply{Text: fmt.Sprintf("Failed to get reviews: %v", err)}
+		}
+	}
+
+	if len(reviews) == 0 {
+		if fetchErr != nil {
+			return Reply{Text: fmt.Sprintf("Failed to fetch reviews: %s", api.Reason(fetchErr))}
+		}
+		return Reply{Text: fmt.Sprintf("No reviews found for %s (%s).", util.EscapeMarkdown(appID), country)}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("*Recent reviews of* %s (%s, %s):", util.EscapeMarkdown(appID), store, country))
+	if fetchErr != nil {
+		sb.WriteString(fmt.Sprintf("\nCould not refresh: %s", api.Reason(fetchErr)))
+	}
+	for _, r := range reviews {
+		sb.WriteString("\n\n")
+		sb.WriteString(review.Format(r))
+	}
+
+	return Reply{Text: sb.String()}
+}
@@ -0,0 +1,193 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/miti99/store-scraper-bot-go/internal/api"
+	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
+	"github.com/miti99/store-scraper-bot-go/internal/api/google"
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+)
+
+const numSearchResults = 5
+
+// countryTokenPrefix marks the country in a search query, e.g. "country:us".
+const countryTokenPrefix = "country:"
+
+// maxCallbackDataLength is Telegram's limit for inline button data.
+const maxCallbackDataLength = 64
+
+var storeArg = Arg{Name: "store", Type: ArgString, Required: true, Choices: []string{"apple", "google"}}
+
+type searchResult struct {
+	AppID     string
+	Title     string
+	Developer string
+	Score     float64
+}
+
+type SearchAppCommand struct {
+	BaseCommand
+	groupRepo     *repository.GroupRepository
+	appleScraper  *apple.AppleScraper
+	googleScraper *google.GoogleScraper
+}
+
+func NewSearchAppCommand(
+	cfg *config.Config,
+	groupRepo *repository.GroupRepository,
+	appleScraper *apple.AppleScraper,
+	googleScraper *google.GoogleScraper,
+) *SearchAppCommand {
+	return &SearchAppCommand{
+		BaseCommand:   BaseCommand{cfg: cfg},
+		groupRepo:     groupRepo,
+		appleScraper:  appleScraper,
+		googleScraper: googleScraper,
+	}
+}
+
+func (c *SearchAppCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "searchapp",
+		Description:  "Search apps by name, optionally in the country given by a country:xx token",
+		Args:         []Arg{storeArg, {Name: "query", Type: ArgText, Required: true}},
+		Example:      "/searchapp google candy crush country:vn",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+	}
+}
+
+func (c *SearchAppCommand) Execute(req *Request) Reply {
+	store := req.Args.String("store")
+	query, country, err := splitQueryCountry(req.Args.String("query"))
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Invalid query: %v", err)}
+	}
+
+	if query == "" {
+		return Reply{Text: c.Metadata().UsageText()}
+	}
+
+	results, err := c.search(store, query, country)
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to search apps: %s", api.Reason(err))}
+	}
+
+	if len(results) == 0 {
+		return Reply{Text: fmt.Sprintf("No %s apps found for \"%s\" (%s).", store, util.EscapeMarkdown(query), country)}
+	}
+
+	var sb strings.Builder
+	var buttons []tgbotapi.InlineKeyboardButton
+	sb.WriteString(fmt.Sprintf("*Search results for* \"%s\" (%s):\n\n", util.EscapeMarkdown(query), country))
+	for i, result := range results {
+		sb.WriteString(fmt.Sprintf("%d. %s\n%s | Score: %.1f\n/add%s %s %s\n\n",
+			i+1, util.Bold(result.Title), util.EscapeMarkdown(result.Developer), result.Score, store, util.EscapeMarkdown(result.AppID), country))
+
+		data := callbackData("searchapp", store, result.AppID, country)
+		if len(data) <= maxCallbackDataLength {
+			buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("Add %d", i+1), data))
+		}
+	}
+
+	reply := Reply{Text: strings.TrimSuffix(sb.String(), "\n")}
+	if len(buttons) > 0 {
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(buttons)
+		reply.Keyboard = &keyboard
+	}
+	return reply
+}
+
+func (c *SearchAppCommand) HandleCallback(req *CallbackRequest) CallbackReply {
+	fields := req.Fields()
+	if len(fields) != 3 {
+		return CallbackReply{Notice: "Invalid button."}
+	}
+	store, appID, country := fields[0], fields[1], fields[2]
+	groupID := req.ChatID()
+
+	var title, primary string
+	var score float64
+	var err error
+	if store == "apple" {
+		app, fetchErr := c.appleScraper.GetApp(appID, country)
+		if fetchErr != nil {
+			return CallbackReply{Notice: fmt.Sprintf("Failed to fetch app from store: %s", api.Reason(fetchErr))}
+		}
+		title, score = app.Title, app.Score
+		primary, err = c.groupRepo.AddAppleApp(groupID, appID, country)
+	} else {
+		app, fetchErr := c.googleScraper.GetApp(appID, country)
+		if fetchErr != nil {
+			return CallbackReply{Notice: fmt.Sprintf("Failed to fetch app from store: %s", api.Reason(fetchErr))}
+		}
+		title, score = app.Title, app.Score
+		primary, err = c.groupRepo.AddGoogleApp(groupID, appID, country)
+	}
+	if err != nil {
+		return CallbackReply{Notice: fmt.Sprintf("Failed to add app: %v", err)}
+	}
+
+	storeTitle := "Google"
+	if store == "apple" {
+		storeTitle = "Apple"
+	}
+
+	return CallbackReply{
+		Notice: fmt.Sprintf("Added %s", title),
+		Message: fmt.Sprintf("%s app added successfully:\n%s\nApp ID: %s\nCountry: %s\nScore: %.1f",
+			storeTitle, util.Bold(title), util.EscapeMarkdown(appID), country, score) + storefrontNote(primary, country),
+		AuditArgs: fmt.Sprintf("add %s %s %s", store, appID, country),
+	}
+}
+
+func (c *SearchAppCommand) search(store, query, country string) ([]searchResult, error) {
+	results := make([]searchResult, 0, numSearchResults)
+
+	if store == "apple" {
+		apps, err := c.appleScraper.Search(query, country, numSearchResults)
+		if err != nil {
+			return nil, err
+		}
+		for _, app := range apps {
+			results = append(results, searchResult{AppID: app.AppID, Title: app.Title, Developer: app.Developer, Score: app.Score})
+		}
+	} else {
+		apps, err := c.googleScraper.Search(query, country, numSearchResults)
+		if err != nil {
+			return nil, err
+		}
+		for _, app := range apps {
+			results = append(results, searchResult{AppID: app.AppID, Title: app.Title, Developer: app.Developer, Score: app.Score})
+		}
+	}
+
+	if len(results) > numSearchResults {
+		results = results[:numSearchResults]
+	}
+	return results, nil
+}
+
+// splitQueryCountry takes the country from a "country:xx" token anywhere in
+// the query, e.g. "candy crush country:us". Trailing words are never guessed
+// to be a country, as "among us" would be. An unknown code is an error.
+func splitQueryCountry(text string) (string, string, error) {
+	words := strings.Fields(text)
+	for i, word := range words {
+		code, ok := strings.CutPrefix(strings.ToLower(word), countryTokenPrefix)
+		if !ok {
+			continue
+		}
+		if !util.IsCountryCode(code) {
+			return "", "", fmt.Errorf("unknown country code: %s", code)
+		}
+		return strings.Join(append(words[:i:i], words[i+1:]...), " "), code, nil
+	}
+
+	return text, defaultCountry, nil
+}
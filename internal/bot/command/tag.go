@@ -0,0 +1,122 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+)
+
+// tagFilterArg optionally limits a report to apps having all given tags.
+var tagFilterArg = Arg{Name: "tags", Type: ArgText}
+
+type TagCommand struct {
+	BaseCommand
+	groupRepo *repository.GroupRepository
+}
+
+func NewTagCommand(cfg *config.Config, groupRepo *repository.GroupRepository) *TagCommand {
+	return &TagCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		groupRepo:   groupRepo,
+	}
+}
+
+func (c *TagCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "tag",
+		Description:  "Add tags to an app, e.g. team:payments platform:ios",
+		Args:         []Arg{storeArg, appIDArg, {Name: "tags", Type: ArgText, Required: true}},
+		Example:      "/tag google com.example.app team:payments genre:finance",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+		Mutating:     true,
+	}
+}
+
+func (c *TagCommand) Execute(req *Request) Reply {
+	appID := req.Args.String("appId")
+	tags := parseTags(req.Args.String("tags"))
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, sortPrefix) {
+			return Reply{Text: fmt.Sprintf("Invalid tag: %s\nTags cannot start with %s, it selects the sort order of /checkappscores.",
+				util.EscapeMarkdown(tag), sortPrefix)}
+		}
+	}
+
+	var err error
+	if req.Args.String("store") == "apple" {
+		err = c.groupRepo.AddAppleAppTags(req.ChatID(), appID, tags)
+	} else {
+		err = c.groupRepo.AddGoogleAppTags(req.ChatID(), appID, tags)
+	}
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to tag app: %v", err)}
+	}
+
+	return Reply{Text: fmt.Sprintf("%s has been tagged with %s.", util.EscapeMarkdown(appID), util.EscapeMarkdown(strings.Join(tags, ", ")))}
+}
+
+type UntagCommand struct {
+	BaseCommand
+	groupRepo *repository.GroupRepository
+}
+
+func NewUntagCommand(cfg *config.Config, groupRepo *repository.GroupRepository) *UntagCommand {
+	return &UntagCommand{
+		BaseCommand: BaseCommand{cfg: cfg},
+		groupRepo:   groupRepo,
+	}
+}
+
+func (c *UntagCommand) Metadata() Metadata {
+	return Metadata{
+		Name:         "untag",
+		Description:  "Remove tags from an app",
+		Args:         []Arg{storeArg, appIDArg, {Name: "tags", Type: ArgText, Required: true}},
+		Example:      "/untag google com.example.app team:payments",
+		Permission:   PermissionAdmin,
+		RequireGroup: true,
+		Mutating:     true,
+	}
+}
+
+func (c *UntagCommand) Execute(req *Request) Reply {
+	appID := req.Args.String("appId")
+	tags := parseTags(req.Args.String("tags"))
+
+	var err error
+	if req.Args.String("store") == "apple" {
+		err = c.groupRepo.RemoveAppleAppTags(req.ChatID(), appID, tags)
+	} else {
+		err = c.groupRepo.RemoveGoogleAppTags(req.ChatID(), appID, tags)
+	}
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("Failed to untag app: %v", err)}
+	}
+
+	return Reply{Text: fmt.Sprintf("Removed %s from %s.", util.EscapeMarkdown(strings.Join(tags, ", ")), util.EscapeMarkdown(appID))}
+}
+
+// parseTags splits space separated tags. Tags are case insensitive.
+func parseTags(text string) []string {
+	return strings.Fields(strings.ToLower(text))
+}
+
+// filterApps returns the apps having all the given tags.
+func filterApps(apps []model.AppInfo, tags []string) []model.AppInfo {
+	if len(tags) == 0 {
+		return apps
+	}
+
+	filtered := make([]model.AppInfo, 0, len(apps))
+	for _, app := range apps {
+		if app.HasTags(tags) {
+			filtered = append(filtered, app)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,186 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/miti99/store-scraper-bot-go/internal/bot/command"
+	"go.uber.org/zap"
+)
+
+const (
+	// menuRequestInterval spaces out the command menu calls, which Telegram
+	// rate limits per bot.
+	menuRequestInterval = 250 * time.Millisecond
+	// maxMenuRetries is how often a call is retried after a 429 response.
+	maxMenuRetries = 3
+)
+
+// publishCommands fills the Telegram command menu from the registry.
+// Everyone gets the public commands; admins additionally get their commands
+// in their private chat and in every registered group, which take precedence
+// over the broader scopes.
+func (b *Bot) publishCommands() error {
+	if err := b.setCommands(tgbotapi.NewBotCommandScopeDefault(),
+		b.registry.Menu(command.PermissionEveryone, command.ChatAny)); err != nil {
+		return err
+	}
+	if err := b.setCommands(tgbotapi.NewBotCommandScopeAllPrivateChats(),
+		b.registry.Menu(command.PermissionEveryone, command.ChatPrivate)); err != nil {
+		return err
+	}
+	if err := b.setCommands(tgbotapi.NewBotCommandScopeAllGroupChats(),
+		b.registry.Menu(command.PermissionEveryone, command.ChatGroup)); err != nil {
+		return err
+	}
+
+	groups, err := b.adminRepo.GetAllGroups()
+	if err != nil {
+		return err
+	}
+
+	for _, adminID := range b.cfg.GetAdminIDs() {
+		b.publishAdminMenu(adminID, groups)
+	}
+
+	b.logger.Info("Published bot commands",
+		zap.Int("admins", len(b.cfg.GetAdminIDs())),
+		zap.Int("groups", len(groups)))
+	return nil
+}
+
+// PublishAdminMenu gives a new admin their commands. Like the other menu
+// updates it runs in the background, as the calls are rate limited.
+func (b *Bot) PublishAdminMenu(userID int64) {
+	go func() {
+		groups, err := b.adminRepo.GetAllGroups()
+		if err != nil {
+			b.logger.Warn("Failed to get groups for admin commands", zap.Int64("userId", userID), zap.Error(err))
+			return
+		}
+		b.publishAdminMenu(userID, groups)
+	}()
+}
+
+// ClearAdminMenu removes the admin scopes of a former admin, who then falls
+// back to the public commands.
+func (b *Bot) ClearAdminMenu(userID int64) {
+	go func() {
+		if err := b.deleteCommands(tgbotapi.NewBotCommandScopeChat(userID)); err != nil {
+			b.logger.Warn("Failed to clear admin commands", zap.Int64("userId", userID), zap.Error(err))
+		}
+
+		groups, err := b.adminRepo.GetAllGroups()
+		if err != nil {
+			b.logger.Warn("Failed to get groups for admin commands", zap.Int64("userId", userID), zap.Error(err))
+			return
+		}
+		for _, groupID := range groups {
+			if err := b.deleteCommands(tgbotapi.NewBotCommandScopeChatMember(groupID, userID)); err != nil {
+				b.logger.Warn("Failed to clear admin group commands",
+					zap.Int64("userId", userID),
+					zap.Int64("groupId", groupID),
+					zap.Error(err))
+			}
+		}
+	}()
+}
+
+// PublishGroupMenu gives every admin their commands in a new group.
+func (b *Bot) PublishGroupMenu(groupID int64) {
+	go func() {
+		for _, adminID := range b.cfg.GetAdminIDs() {
+			if err := b.setCommands(tgbotapi.NewBotCommandScopeChatMember(groupID, adminID),
+				b.registry.Menu(b.menuPermission(adminID), command.ChatGroup)); err != nil {
+				b.logger.Warn("Failed to publish admin group commands",
+					zap.Int64("userId", adminID),
+					zap.Int64("groupId", groupID),
+					zap.Error(err))
+			}
+		}
+	}()
+}
+
+// ClearGroupMenu removes the admin scopes of a deleted group.
+func (b *Bot) ClearGroupMenu(groupID int64) {
+	go func() {
+		for _, adminID := range b.cfg.GetAdminIDs() {
+			if err := b.deleteCommands(tgbotapi.NewBotCommandScopeChatMember(groupID, adminID)); err != nil {
+				b.logger.Warn("Failed to clear admin group commands",
+					zap.Int64("userId", adminID),
+					zap.Int64("groupId", groupID),
+					zap.Error(err))
+			}
+		}
+	}()
+}
+
+func (b *Bot) publishAdminMenu(adminID int64, groups []int64) {
+	permission := b.menuPermission(adminID)
+
+	// A private chat has the same ID as the user
+	if err := b.setCommands(tgbotapi.NewBotCommandScopeChat(adminID),
+		b.registry.Menu(permission, command.ChatPrivate)); err != nil {
+		b.logger.Warn("Failed to publish admin commands",
+			zap.Int64("userId", adminID),
+			zap.Error(err))
+	}
+
+	groupMenu := b.registry.Menu(permission, command.ChatGroup)
+	for _, groupID := range groups {
+		if err := b.setCommands(tgbotapi.NewBotCommandScopeChatMember(groupID, adminID), groupMenu); err != nil {
+			b.logger.Warn("Failed to publish admin group commands",
+				zap.Int64("userId", adminID),
+				zap.Int64("groupId", groupID),
+				zap.Error(err))
+		}
+	}
+}
+
+func (b *Bot) menuPermission(adminID int64) command.Permission {
+	if b.cfg.IsCreator(adminID) {
+		return command.PermissionCreator
+	}
+	return command.PermissionAdmin
+}
+
+func (b *Bot) setCommands(scope tgbotapi.BotCommandScope, commands []tgbotapi.BotCommand) error {
+	if err := b.requestMenu(tgbotapi.NewSetMyCommandsWithScope(scope, commands...)); err != nil {
+		return fmt.Errorf("failed to set commands for scope %s: %w", scope.Type, err)
+	}
+	return nil
+}
+
+func (b *Bot) deleteCommands(scope tgbotapi.BotCommandScope) error {
+	if err := b.requestMenu(tgbotapi.NewDeleteMyCommandsWithScope(scope)); err != nil {
+		return fmt.Errorf("failed to delete commands for scope %s: %w", scope.Type, err)
+	}
+	return nil
+}
+
+// requestMenu sends one menu call at a time, at most one per
+// menuRequestInterval, and waits out retry_after when rate limited.
+func (b *Bot) requestMenu(config tgbotapi.Chattable) error {
+	b.menuMu.Lock()
+	defer b.menuMu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		if wait := menuRequestInterval - time.Since(b.menuLastRequest); wait > 0 {
+			time.Sleep(wait)
+		}
+
+		// The menu calls return a bool, so use Request rather than Send
+		_, err := b.api.Request(config)
+		b.menuLastRequest = time.Now()
+
+		var apiErr *tgbotapi.Error
+		if err == nil || !errors.As(err, &apiErr) || apiErr.RetryAfter <= 0 || attempt >= maxMenuRetries {
+			return err
+		}
+
+		b.logger.Warn("Command menu rate limited", zap.Int("retryAfter", apiErr.RetryAfter))
+		time.Sleep(time.Duration(apiErr.RetryAfter) * time.Second)
+	}
+}
@@ -0,0 +1,225 @@
+// Package chart renders simple PNG charts with the standard library only.
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"time"
+)
+
+const (
+	width       = 800
+	panelHeight = 260
+
+	marginLeft   = 80
+	marginRight  = 24
+	marginTop    = 32
+	marginBottom = 32
+
+	gridLines = 4
+)
+
+var (
+	colorBackground = color.RGBA{255, 255, 255, 255}
+	colorAxis       = color.RGBA{96, 96, 96, 255}
+	colorGrid       = color.RGBA{228, 228, 228, 255}
+	colorText       = color.RGBA{48, 48, 48, 255}
+)
+
+// Palette holds the default series colors, used in order.
+var Palette = []color.RGBA{
+	{33, 150, 243, 255},
+	{76, 175, 80, 255},
+	{255, 152, 0, 255},
+	{156, 39, 176, 255},
+}
+
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series is one line. Points must be sorted by time.
+type Series struct {
+	Name   string
+	Points []Point
+	Color  color.RGBA // Zero value picks from Palette
+}
+
+// LineChart renders each series in its own panel with its own value scale,
+// stacked vertically over a shared time axis, and returns the PNG bytes.
+func LineChart(series ...Series) ([]byte, error) {
+	if len(series) == 0 {
+		return nil, fmt.Errorf("no series to render")
+	}
+
+	start, end, ok := timeRange(series)
+	if !ok {
+		return nil, fmt.Errorf("no points to render")
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, panelHeight*len(series)))
+	fillRect(img, 0, 0, img.Bounds().Dx(), img.Bounds().Dy(), colorBackground)
+
+	for i, s := range series {
+		if s.Color == (color.RGBA{}) {
+			s.Color = Palette[i%len(Palette)]
+		}
+		drawPanel(img, i*panelHeight, s, start, end)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode chart: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func drawPanel(img *image.RGBA, top int, s Series, start, end time.Time) {
+	left, right := marginLeft, width-marginRight
+	plotTop, plotBottom := top+marginTop, top+panelHeight-marginBottom
+
+	drawText(img, left, top+10, s.Name, colorText)
+
+	low, high := valueRange(s.Points)
+
+	// Grid with value labels
+	for i := 0; i <= gridLines; i++ {
+		y := plotBottom - (plotBottom-plotTop)*i/gridLines
+		drawLine(img, left, y, right, y, 1, colorGrid)
+
+		label := formatValue(low + (high-low)*float64(i)/gridLines)
+		drawText(img, left-8-textWidth(label), y-glyphHeight*fontScale/2, label, colorText)
+	}
+
+	// Axes
+	drawLine(img, left, plotTop, left, plotBottom, 1, colorAxis)
+	drawLine(img, left, plotBottom, right, plotBottom, 1, colorAxis)
+
+	// Date labels at both ends
+	dateY := plotBottom + 8
+	drawText(img, left, dateY, start.Format("2006-01-02"), colorText)
+	endLabel := end.Format("2006-01-02")
+	drawText(img, right-textWidth(endLabel), dateY, endLabel, colorText)
+
+	x := func(t time.Time) int {
+		span := end.Sub(start)
+		if span <= 0 {
+			return (left + right) / 2
+		}
+		return left + int(float64(right-left)*float64(t.Sub(start))/float64(span))
+	}
+	y := func(v float64) int {
+		return plotBottom - int(float64(plotBottom-plotTop)*(v-low)/(high-low))
+	}
+
+	for i, p := range s.Points {
+		px, py := x(p.Time), y(p.Value)
+		if i > 0 {
+			prev := s.Points[i-1]
+			drawLine(img, x(prev.Time), y(prev.Value), px, py, 2, s.Color)
+		}
+		fillRect(img, px-2, py-2, 5, 5, s.Color)
+	}
+}
+
+func timeRange(series []Series) (time.Time, time.Time, bool) {
+	var start, end time.Time
+	found := false
+	for _, s := range series {
+		for _, p := range s.Points {
+			if !found || p.Time.Before(start) {
+				start = p.Time
+			}
+			if !found || p.Time.After(end) {
+				end = p.Time
+			}
+			found = true
+		}
+	}
+	return start, end, found
+}
+
+// valueRange returns the padded value scale of the points. A flat line is
+// centered instead of dividing by zero.
+func valueRange(points []Point) (float64, float64) {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, p := range points {
+		low = math.Min(low, p.Value)
+		high = math.Max(high, p.Value)
+	}
+	if len(points) == 0 {
+		return 0, 1
+	}
+
+	if high == low {
+		pad := math.Max(math.Abs(high)*0.05, 0.5)
+		return low - pad, high + pad
+	}
+
+	pad := (high - low) * 0.05
+	return low - pad, high + pad
+}
+
+func formatValue(v float64) string {
+	abs := math.Abs(v)
+	switch {
+	case abs >= 1000000:
+		return fmt.Sprintf("%.1fM", v/1000000)
+	case abs >= 1000:
+		return fmt.Sprintf("%.1fK", v/1000)
+	case abs >= 100:
+		return fmt.Sprintf("%.0f", v)
+	default:
+		return fmt.Sprintf("%.2f", v)
+	}
+}
+
+func fillRect(img *image.RGBA, x, y, w, h int, c color.Color) {
+	for dy := 0; dy < h; dy++ {
+		for dx := 0; dx < w; dx++ {
+			img.Set(x+dx, y+dy, c)
+		}
+	}
+}
+
+// drawLine draws a line of the given thickness with Bresenham's algorithm.
+func drawLine(img *image.RGBA, x0, y0, x1, y1, thickness int, c color.Color) {
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+
+	err := dx + dy
+	for {
+		fillRect(img, x0-thickness/2, y0-thickness/2, thickness, thickness, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
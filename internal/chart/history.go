@@ -0,0 +1,14 @@
+package chart
+
+import "github.com/miti99/store-scraper-bot-go/internal/model"
+
+// History renders the score and ratings of app snapshots, oldest first.
+func History(snapshots []model.AppSnapshot) ([]byte, error) {
+	score := Series{Name: "Score"}
+	ratings := Series{Name: "Ratings"}
+	for _, s := range snapshots {
+		score.Points = append(score.Points, Point{Time: s.CapturedAt, Value: s.Score})
+		ratings.Points = append(ratings.Points, Point{Time: s.CapturedAt, Value: float64(s.Ratings)})
+	}
+	return LineChart(score, ratings)
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -23,9 +24,9 @@ type Config struct {
 	TelegramBotUsername string
 
 	// MongoDB
-	MongoURI        string
-	MongoDatabase   string
-	MongoTimeout    time.Duration
+	MongoURI      string
+	MongoDatabase string
+	MongoTimeout  time.Duration
 
 	// Application
 	Env          Environment
@@ -34,13 +35,28 @@ type Config struct {
 	SourceCommit string
 
 	// Constants
-	AppCacheSeconds           int
-	NumDaysWarningNotUpdated  int
-	ScheduleCheckAppTime      string
-	VietnamLocation           *time.Location
+	AppCacheSeconds            int
+	NumDaysWarningNotUpdated   int
+	ScheduleCheckAppTime       string
+	ScheduleCheckDeveloperTime string
+	ScheduleSnapshotTime       string
+	ScheduleCheckReviewTime    string
+	ScheduleWeeklyDigestTime   string
+	ScheduleMonthlyDigestTime  string
+	ScheduleNotifyChangesTime  string
+	ScheduleCheckRankTime      string
+	NumNotFoundAlertThreshold  int
+	ReviewAlertMaxScore        int
+	ReviewSummarySize          int
+	RankChartSize              int
+	RankAlertTop               int
+	VietnamLocation            *time.Location
 
 	// Logger
 	Logger *zap.Logger
+
+	envAdminIDs []int64
+	adminMu     sync.RWMutex
 }
 
 var GlobalConfig *Config
@@ -80,6 +96,7 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("at least one admin ID is required")
 	}
 	cfg.CreatorID = cfg.AdminIDs[0]
+	cfg.envAdminIDs = append([]int64(nil), cfg.AdminIDs...)
 
 	cfg.SourceCommit = getEnv("SOURCE_COMMIT", "unknown")
 
@@ -87,6 +104,18 @@ func Load() (*Config, error) {
 	cfg.AppCacheSeconds = getEnvInt("APP_CACHE_SECONDS", 600)
 	cfg.NumDaysWarningNotUpdated = getEnvInt("NUM_DAYS_WARNING_NOT_UPDATED", 30)
 	cfg.ScheduleCheckAppTime = getEnv("SCHEDULE_CHECK_APP_TIME", "0 7 * * *") // Cron format: 7:00 AM daily
+	cfg.ScheduleCheckDeveloperTime = getEnv("SCHEDULE_CHECK_DEVELOPER_TIME", "0 */6 * * *")
+	cfg.ScheduleSnapshotTime = getEnv("SCHEDULE_SNAPSHOT_TIME", "0 5 * * *") // Daily history for trend metrics
+	cfg.NumNotFoundAlertThreshold = getEnvInt("NUM_NOT_FOUND_ALERT_THRESHOLD", 2)
+	cfg.ScheduleCheckReviewTime = getEnv("SCHEDULE_CHECK_REVIEW_TIME", "0 * * * *")
+	cfg.ReviewAlertMaxScore = getEnvInt("REVIEW_ALERT_MAX_SCORE", 2)                            // Post new reviews with at most this many stars
+	cfg.ScheduleWeeklyDigestTime = getEnvOptional("SCHEDULE_WEEKLY_DIGEST_TIME", "0 9 * * 1")   // Monday 9:00 AM, empty to disable
+	cfg.ScheduleMonthlyDigestTime = getEnvOptional("SCHEDULE_MONTHLY_DIGEST_TIME", "0 9 1 * *") // 1st of the month 9:00 AM, empty to disable
+	cfg.ReviewSummarySize = getEnvInt("REVIEW_SUMMARY_SIZE", 200)                               // Latest reviews analyzed per app
+	cfg.ScheduleNotifyChangesTime = getEnv("SCHEDULE_NOTIFY_CHANGES_TIME", "*/15 * * * *")      // Post detected listing changes
+	cfg.ScheduleCheckRankTime = getEnv("SCHEDULE_CHECK_RANK_TIME", "0 6 * * *")                 // Daily top chart capture
+	cfg.RankChartSize = getEnvInt("RANK_CHART_SIZE", 100)                                       // Chart positions captured
+	cfg.RankAlertTop = getEnvInt("RANK_ALERT_TOP", 10)                                          // Alert when apps enter or leave the top N
 
 	// Vietnam timezone
 	loc, err := time.LoadLocation("Asia/Ho_Chi_Minh")
@@ -118,6 +147,15 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvOptional is like getEnv, but a variable set to empty overrides the
+// default, e.g. to disable a schedule.
+func getEnvOptional(key, defaultValue string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
 func getEnvInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
 		if intVal, err := strconv.Atoi(value); err == nil {
@@ -140,8 +178,64 @@ func parseAdminIDs(adminIDsStr string) []int64 {
 }
 
 func (c *Config) IsAdmin(userID int64) bool {
-	for _, adminID := range c.AdminIDs {
+	c.adminMu.RLock()
+	defer c.adminMu.RUnlock()
+
+	return containsID(c.AdminIDs, userID)
+}
+
+func (c *Config) IsCreator(userID int64) bool {
+	return c.CreatorID == userID
+}
+
+// IsEnvAdmin reports whether the user is configured through ADMIN_IDS.
+// Such admins can only be removed by changing the environment.
+func (c *Config) IsEnvAdmin(userID int64) bool {
+	return containsID(c.envAdminIDs, userID)
+}
+
+// GetAdminIDs returns a copy of the current admin list.
+func (c *Config) GetAdminIDs() []int64 {
+	c.adminMu.RLock()
+	defer c.adminMu.RUnlock()
+
+	return append([]int64(nil), c.AdminIDs...)
+}
+
+// AddAdminIDs merges runtime admins (e.g. persisted in the database) into
+// the env-configured ones.
+func (c *Config) AddAdminIDs(userIDs ...int64) {
+	c.adminMu.Lock()
+	defer c.adminMu.Unlock()
+
+	for _, userID := range userIDs {
+		if !containsID(c.AdminIDs, userID) {
+			c.AdminIDs = append(c.AdminIDs, userID)
+		}
+	}
+}
+
+// RemoveAdminID removes a runtime admin. Env-configured admins are kept.
+func (c *Config) RemoveAdminID(userID int64) bool {
+	if c.IsEnvAdmin(userID) {
+		return false
+	}
+
+	c.adminMu.Lock()
+	defer c.adminMu.Unlock()
+
+	for i, adminID := range c.AdminIDs {
 		if adminID == userID {
+			c.AdminIDs = append(c.AdminIDs[:i], c.AdminIDs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
 			return true
 		}
 	}
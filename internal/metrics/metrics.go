@@ -0,0 +1,119 @@
+// Package metrics derives trends from the daily app snapshots.
+package metrics
+
+import (
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+)
+
+// minSpan is the shortest history a per-day rate is computed over, so a
+// couple of snapshots taken hours apart do not produce wild numbers.
+const minSpan = 24 * time.Hour
+
+// Since returns the snapshots captured at or after t. Snapshots must be
+// sorted oldest first.
+func Since(snapshots []model.AppSnapshot, t time.Time) []model.AppSnapshot {
+	for i, s := range snapshots {
+		if !s.CapturedAt.Before(t) {
+			return snapshots[i:]
+		}
+	}
+	return nil
+}
+
+// TrendDays is how much history Compute needs.
+const TrendDays = 30
+
+// Compute derives the growth metrics from snapshots of the last TrendDays
+// days, sorted oldest first.
+func Compute(snapshots []model.AppSnapshot, now time.Time) model.Trend {
+	week := Since(snapshots, now.AddDate(0, 0, -7))
+	month := Since(snapshots, now.AddDate(0, 0, -TrendDays))
+
+	var trend model.Trend
+	trend.RatingsPerDay7 = rate(RatingsPerDay(week))
+	trend.RatingsPerDay30 = rate(RatingsPerDay(month))
+	trend.ReviewsPerDay7 = rate(ReviewsPerDay(week))
+	trend.ReviewsPerDay30 = rate(ReviewsPerDay(month))
+	trend.ScoreDelta30 = rate(ScoreDelta(month))
+	return trend
+}
+
+func rate(value float64, ok bool) model.Rate {
+	return model.Rate{Value: value, OK: ok}
+}
+
+// RatingsPerDay returns the average number of new ratings per day between the
+// first and last snapshot. It reports false when the history is too short.
+func RatingsPerDay(snapshots []model.AppSnapshot) (float64, bool) {
+	return perDay(snapshots, func(s model.AppSnapshot) int64 { return s.Ratings })
+}
+
+// ReviewsPerDay returns the average number of new reviews per day, like
+// RatingsPerDay.
+func ReviewsPerDay(snapshots []model.AppSnapshot) (float64, bool) {
+	return perDay(snapshots, func(s model.AppSnapshot) int64 { return s.Reviews })
+}
+
+// ScoreDelta returns the score change between the first and last snapshot.
+func ScoreDelta(snapshots []model.AppSnapshot) (float64, bool) {
+	if !spansMinimum(snapshots) {
+		return 0, false
+	}
+	return snapshots[len(snapshots)-1].Score - snapshots[0].Score, true
+}
+
+func spansMinimum(snapshots []model.AppSnapshot) bool {
+	if len(snapshots) < 2 {
+		return false
+	}
+	return snapshots[len(snapshots)-1].CapturedAt.Sub(snapshots[0].CapturedAt) >= minSpan
+}
+
+func perDay(snapshots []model.AppSnapshot, value func(model.AppSnapshot) int64) (float64, bool) {
+	if !spansMinimum(snapshots) {
+		return 0, false
+	}
+
+	first, last := snapshots[0], snapshots[len(snapshots)-1]
+	days := last.CapturedAt.Sub(first.CapturedAt).Hours() / 24
+	return float64(value(last)-value(first)) / days, true
+}
+
+// UpdateCount returns the number of store updates released at or after t,
+// counting each distinct update time seen in the snapshots once.
+func UpdateCount(snapshots []model.AppSnapshot, t time.Time) int {
+	// Key by Unix time, equal times may differ as map keys by location
+	seen := make(map[int64]bool)
+	for _, s := range snapshots {
+		if s.Updated.IsZero() || s.Updated.Before(t) {
+			continue
+		}
+		seen[s.Updated.Unix()] = true
+	}
+	return len(seen)
+}
+
+// RatingsGained returns the number of new ratings between the first and last
+// snapshot.
+func RatingsGained(snapshots []model.AppSnapshot) (int64, bool) {
+	if !spansMinimum(snapshots) {
+		return 0, false
+	}
+	return snapshots[len(snapshots)-1].Ratings - snapshots[0].Ratings, true
+}
+
+// NewVersions returns the versions released at or after t, oldest first.
+func NewVersions(snapshots []model.AppSnapshot, t time.Time) []string {
+	var versions []string
+	seen := make(map[string]bool)
+	for _, s := range snapshots {
+		if s.Version == "" || s.Updated.IsZero() || s.Updated.Before(t) || seen[s.Version] {
+			continue
+		}
+		seen[s.Version] = true
+		versions = append(versions, s.Version)
+	}
+	return versions
+}
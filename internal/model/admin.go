@@ -1,14 +1,25 @@
 package model
 
+import "time"
+
 type Admin struct {
-	Key    string  `bson:"_id,omitempty" json:"key"`
-	Groups []int64 `bson:"groups" json:"groups"`
+	Key    string      `bson:"_id,omitempty" json:"key"`
+	Groups []int64     `bson:"groups" json:"groups"`
+	Admins []AdminUser `bson:"admins" json:"admins"`
+}
+
+// AdminUser is an admin added at runtime with /addadmin.
+type AdminUser struct {
+	UserID  int64     `bson:"userId" json:"userId"`
+	AddedBy int64     `bson:"addedBy" json:"addedBy"`
+	AddedAt time.Time `bson:"addedAt" json:"addedAt"`
 }
 
 func NewAdmin() *Admin {
 	return &Admin{
 		Key:    "admin",
 		Groups: make([]int64, 0),
+		Admins: make([]AdminUser, 0),
 	}
 }
 
@@ -40,3 +51,27 @@ func (a *Admin) HasGroup(groupID int64) bool {
 	}
 	return false
 }
+
+func (a *Admin) AddAdmin(userID, addedBy int64) bool {
+	for _, u := range a.Admins {
+		if u.UserID == userID {
+			return false // Already exists
+		}
+	}
+	a.Admins = append(a.Admins, AdminUser{
+		UserID:  userID,
+		AddedBy: addedBy,
+		AddedAt: time.Now(),
+	})
+	return true
+}
+
+func (a *Admin) RemoveAdmin(userID int64) bool {
+	for i, u := range a.Admins {
+		if u.UserID == userID {
+			a.Admins = append(a.Admins[:i], a.Admins[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,7 @@
+package model
+
+// AppKey identifies an app in one storefront. Store data such as score and
+// version differs per country, so cached entries are keyed by both.
+func AppKey(appID, country string) string {
+	return country + ":" + appID
+}
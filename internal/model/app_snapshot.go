@@ -0,0 +1,112 @@
+package model
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	StoreApple  = "apple"
+	StoreGoogle = "google"
+)
+
+// AppSnapshot is the state of an app in one storefront on one day. The
+// scrapers overwrite the snapshot of the current day on every fetch, so the
+// history holds the last known state per day.
+type AppSnapshot struct {
+	Key          string           `bson:"_id" json:"key"`
+	Store        string           `bson:"store" json:"store"`
+	AppID        string           `bson:"appId" json:"appId"`
+	Country      string           `bson:"country" json:"country"`
+	Date         string           `bson:"date" json:"date"` // YYYY-MM-DD (UTC)
+	Title        string           `bson:"title" json:"title"`
+	Score        float64          `bson:"score" json:"score"`
+	Ratings      int64            `bson:"ratings" json:"ratings"`
+	Reviews      int64            `bson:"reviews" json:"reviews"`
+	Version      string           `bson:"version" json:"version"`
+	ReleaseNotes string           `bson:"releaseNotes,omitempty" json:"releaseNotes,omitempty"`
+	Updated      time.Time        `bson:"updated" json:"updated"` // Last store update
+	Histogram    map[string]int64 `bson:"histogram" json:"histogram"`
+	CapturedAt   time.Time        `bson:"capturedAt" json:"capturedAt"`
+
+	// Store listing, diffed to detect metadata changes
+	Icon          string   `bson:"icon,omitempty" json:"icon,omitempty"`
+	Screenshots   []string `bson:"screenshots,omitempty" json:"screenshots,omitempty"`
+	Description   string   `bson:"description,omitempty" json:"description,omitempty"`
+	Price         float64  `bson:"price" json:"price"`
+	Currency      string   `bson:"currency,omitempty" json:"currency,omitempty"`
+	Free          bool     `bson:"free" json:"free"`
+	ContentRating string   `bson:"contentRating,omitempty" json:"contentRating,omitempty"`
+}
+
+// VersionRecord is one released version as seen in the snapshot history.
+type VersionRecord struct {
+	Version      string    `bson:"_id" json:"version"`
+	ReleaseNotes string    `bson:"releaseNotes" json:"releaseNotes"`
+	Updated      time.Time `bson:"updated" json:"updated"`
+	FirstSeen    time.Time `bson:"firstSeen" json:"firstSeen"`
+}
+
+func newAppSnapshot(store, appID, country string) *AppSnapshot {
+	now := time.Now().UTC()
+	date := now.Format("2006-01-02")
+	return &AppSnapshot{
+		Key:        fmt.Sprintf("%s:%s:%s:%s", store, country, appID, date),
+		Store:      store,
+		AppID:      appID,
+		Country:    country,
+		Date:       date,
+		CapturedAt: now,
+	}
+}
+
+func NewAppleSnapshot(appID, country string, app AppleAppResponse) *AppSnapshot {
+	s := newAppSnapshot(StoreApple, appID, country)
+	s.Title = app.Title
+	s.Score = app.Score
+	s.Ratings = app.Ratings
+	s.Reviews = int64(app.Reviews)
+	s.Version = app.Version
+	s.ReleaseNotes = strings.TrimSpace(app.ReleaseNotes)
+	s.Updated, _ = time.Parse(time.RFC3339, app.Updated)
+	s.Histogram = app.Histogram
+	s.Icon = app.Icon
+	s.Screenshots = app.Screenshots
+	s.Description = app.Description
+	s.Price, s.Currency, s.Free = app.Price, app.Currency, app.Free
+	s.ContentRating = app.ContentRating
+	return s
+}
+
+func NewGoogleSnapshot(appID, country string, app GoogleAppResponse) *AppSnapshot {
+	s := newAppSnapshot(StoreGoogle, appID, country)
+	s.Title = app.Title
+	s.Score = app.Score
+	s.Ratings = app.Ratings
+	s.Reviews = app.Reviews
+	s.Version = app.Version
+	s.ReleaseNotes = cleanReleaseNotes(app.RecentChanges)
+	s.Updated = time.UnixMilli(app.Updated)
+	s.Histogram = app.Histogram
+	s.Icon = app.Icon
+	s.Screenshots = app.Screenshots
+	s.Description = app.Description
+	s.Price, s.Currency, s.Free = app.Price, app.Currency, app.Free
+	s.ContentRating = app.ContentRating
+	return s
+}
+
+var (
+	lineBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>`)
+	htmlTagPattern   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// cleanReleaseNotes turns Google's HTML release notes into plain text.
+func cleanReleaseNotes(notes string) string {
+	notes = lineBreakPattern.ReplaceAllString(notes, "\n")
+	notes = htmlTagPattern.ReplaceAllString(notes, "")
+	return strings.TrimSpace(html.UnescapeString(notes))
+}
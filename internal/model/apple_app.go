@@ -23,6 +23,7 @@ type AppleAppResponse struct {
 	Released           string            `json:"released"`
 	Updated            string            `json:"updated"` // ISO 8601 timestamp
 	Version            string            `json:"version"`
+	ReleaseNotes       string            `json:"releaseNotes"` // What's new in this version
 	Price              float64           `json:"price"`
 	Currency           string            `json:"currency"`
 	Free               bool              `json:"free"`
@@ -36,9 +37,9 @@ type AppleAppResponse struct {
 	Histogram          map[string]int64  `json:"histogram"`
 }
 
-func NewAppleApp(appID string, response AppleAppResponse) *AppleApp {
+func NewAppleApp(appID, country string, response AppleAppResponse) *AppleApp {
 	return &AppleApp{
-		Key:       appID,
+		Key:       AppKey(appID, country),
 		App:       response,
 		UpdatedAt: time.Now(),
 	}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AuditEntry struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    int64              `bson:"userId" json:"userId"`
+	ChatID    int64              `bson:"chatId" json:"chatId"`
+	Command   string             `bson:"command" json:"command"`
+	Arguments string             `bson:"arguments" json:"arguments"`
+	Result    string             `bson:"result" json:"result"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+func NewAuditEntry(userID, chatID int64, command, arguments, result string) *AuditEntry {
+	return &AuditEntry{
+		UserID:    userID,
+		ChatID:    chatID,
+		Command:   command,
+		Arguments: arguments,
+		Result:    result,
+		CreatedAt: time.Now(),
+	}
+}
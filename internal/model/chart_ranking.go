@@ -0,0 +1,95 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Top chart collections, mapped to each store's own names by the scrapers
+const (
+	ChartTopFree     = "topfree"
+	ChartTopPaid     = "toppaid"
+	ChartTopGrossing = "topgrossing"
+)
+
+var ChartCollections = []string{ChartTopFree, ChartTopPaid, ChartTopGrossing}
+
+// ChartInfo is a top chart tracked by a group. An empty category is the
+// overall chart of the store.
+type ChartInfo struct {
+	Store      string `bson:"store" json:"store"`
+	Collection string `bson:"collection" json:"collection"`
+	Category   string `bson:"category,omitempty" json:"category,omitempty"` // Store category ID, e.g. "6014" or "GAME"
+	Country    string `bson:"country" json:"country"`
+}
+
+func (c ChartInfo) Key() string {
+	return fmt.Sprintf("%s:%s:%s:%s", c.Store, c.Collection, c.Category, c.Country)
+}
+
+// Name renders the collection and category, e.g. "topfree/GAME".
+func (c ChartInfo) Name() string {
+	if c.Category == "" {
+		return c.Collection
+	}
+	return c.Collection + "/" + c.Category
+}
+
+// ChartEntry is an app's position in a chart, starting at 1.
+type ChartEntry struct {
+	Position int    `bson:"position" json:"position"`
+	AppID    string `bson:"appId" json:"appId"`
+	Title    string `bson:"title" json:"title"`
+}
+
+// ChartRanking is a chart as captured on one day (UTC). Capturing the same
+// chart again on the same day overwrites it.
+type ChartRanking struct {
+	Key        string       `bson:"_id" json:"key"` // chart key + ":" + date
+	ChartKey   string       `bson:"chartKey" json:"chartKey"`
+	Chart      ChartInfo    `bson:"chart" json:"chart"`
+	Date       string       `bson:"date" json:"date"` // YYYY-MM-DD
+	Entries    []ChartEntry `bson:"entries" json:"entries"`
+	CapturedAt time.Time    `bson:"capturedAt" json:"capturedAt"`
+}
+
+func NewChartRanking(chart ChartInfo, entries []ChartEntry) *ChartRanking {
+	now := time.Now().UTC()
+	date := now.Format("2006-01-02")
+	return &ChartRanking{
+		Key:        chart.Key() + ":" + date,
+		ChartKey:   chart.Key(),
+		Chart:      chart,
+		Date:       date,
+		Entries:    entries,
+		CapturedAt: now,
+	}
+}
+
+// Position returns the app's position, 0 if it is not in the chart.
+func (r *ChartRanking) Position(appID string) int {
+	for _, e := range r.Entries {
+		if e.AppID == appID {
+			return e.Position
+		}
+	}
+	return 0
+}
+
+// NewAppleChartEntries ranks the apps of an Apple chart response in order.
+func NewAppleChartEntries(apps []AppleAppResponse) []ChartEntry {
+	entries := make([]ChartEntry, 0, len(apps))
+	for i, app := range apps {
+		entries = append(entries, ChartEntry{Position: i + 1, AppID: app.AppID, Title: app.Title})
+	}
+	return entries
+}
+
+// NewGoogleChartEntries ranks the apps of a Google chart response in order.
+func NewGoogleChartEntries(apps []GoogleAppResponse) []ChartEntry {
+	entries := make([]ChartEntry, 0, len(apps))
+	for i, app := range apps {
+		entries = append(entries, ChartEntry{Position: i + 1, AppID: app.AppID, Title: app.Title})
+	}
+	return entries
+}
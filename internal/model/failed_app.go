@@ -0,0 +1,18 @@
+package model
+
+// FailedApp is an app that could not be checked in a report.
+type FailedApp struct {
+	AppID   string
+	Country string
+	Error   string // Error class, e.g. "rate limited"
+	IsApple bool
+}
+
+func NewFailedApp(appInfo AppInfo, errorClass string, isApple bool) FailedApp {
+	return FailedApp{
+		AppID:   appInfo.AppID,
+		Country: appInfo.Country,
+		Error:   errorClass,
+		IsApple: isApple,
+	}
+}
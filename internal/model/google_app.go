@@ -31,13 +31,14 @@ type GoogleAppResponse struct {
 	AdSupported    bool              `json:"adSupported"`
 	Updated        int64             `json:"updated"` // Milliseconds since epoch
 	Version        string            `json:"version"`
+	RecentChanges  string            `json:"recentChanges"` // What's new, may contain HTML line breaks
 	AppID          string            `json:"appId"`
 	URL            string            `json:"url"`
 }
 
-func NewGoogleApp(appID string, response GoogleAppResponse) *GoogleApp {
+func NewGoogleApp(appID, country string, response GoogleAppResponse) *GoogleApp {
 	return &GoogleApp{
-		Key:       appID,
+		Key:       AppKey(appID, country),
 		App:       response,
 		UpdatedAt: time.Now(),
 	}
@@ -1,32 +1,171 @@
 package model
 
+import "time"
+
 type AppInfo struct {
-	AppID   string `bson:"appId" json:"appId"`
-	Country string `bson:"country" json:"country"`
+	AppID          string         `bson:"appId" json:"appId"`
+	Country        string         `bson:"country" json:"country"`                                   // Primary storefront, used by scheduled checks
+	Countries      []string       `bson:"countries,omitempty" json:"countries,omitempty"`           // Additional storefronts, compared by /compare
+	Muted          bool           `bson:"muted,omitempty" json:"muted,omitempty"`                   // Excluded from scheduled reports
+	NotFoundCount  int            `bson:"notFoundCount,omitempty" json:"notFoundCount,omitempty"`   // Consecutive not-found checks of the primary storefront, before NotFoundCounts
+	NotFoundCounts map[string]int `bson:"notFoundCounts,omitempty" json:"notFoundCounts,omitempty"` // Consecutive checks the store did not list the app, per storefront
+	Label          string         `bson:"label,omitempty" json:"label,omitempty"`                   // Benchmark label, e.g. "own" or "competitor"
+	Tags           []string       `bson:"tags,omitempty" json:"tags,omitempty"`                     // Free-form tags for filtering, e.g. "team:payments"
+}
+
+const (
+	LabelOwn        = "own"
+	LabelCompetitor = "competitor"
+	LabelNone       = "unlabeled"
+)
+
+// LabelOrDefault returns the benchmark label, LabelNone if unset.
+func (a AppInfo) LabelOrDefault() string {
+	if a.Label == "" {
+		return LabelNone
+	}
+	return a.Label
+}
+
+// Storefronts returns every country the app is tracked in, primary first.
+func (a AppInfo) Storefronts() []string {
+	return append([]string{a.Country}, a.Countries...)
+}
+
+// NotFoundIn returns the consecutive checks the storefront did not list the
+// app in, falling back to the older count of the primary storefront.
+func (a AppInfo) NotFoundIn(country string) int {
+	if count, ok := a.NotFoundCounts[country]; ok {
+		return count
+	}
+	if country == a.Country {
+		return a.NotFoundCount
+	}
+	return 0
+}
+
+func (a AppInfo) HasStorefront(country string) bool {
+	for _, c := range a.Storefronts() {
+		if c == country {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTags reports whether the app has every one of the given tags.
+func (a AppInfo) HasTags(tags []string) bool {
+	for _, tag := range tags {
+		found := false
+		for _, t := range a.Tags {
+			if t == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *AppInfo) addTags(tags []string) {
+	for _, tag := range tags {
+		if !a.HasTags([]string{tag}) {
+			a.Tags = append(a.Tags, tag)
+		}
+	}
+}
+
+func (a *AppInfo) removeTags(tags []string) {
+	kept := a.Tags[:0]
+	for _, t := range a.Tags {
+		remove := false
+		for _, tag := range tags {
+			if t == tag {
+				remove = true
+				break
+			}
+		}
+		if !remove {
+			kept = append(kept, t)
+		}
+	}
+	a.Tags = kept
+}
+
+// DeveloperInfo is a developer account whose apps are tracked automatically.
+type DeveloperInfo struct {
+	DeveloperID string   `bson:"developerId" json:"developerId"`
+	Name        string   `bson:"name" json:"name"`
+	Country     string   `bson:"country" json:"country"`
+	AppIDs      []string `bson:"appIds" json:"appIds"` // Apps seen in the last enumeration
+}
+
+// ReviewRule alerts the group when a new review mentions the keyword.
+type ReviewRule struct {
+	Keyword string    `bson:"keyword" json:"keyword"`
+	AddedBy int64     `bson:"addedBy" json:"addedBy"`
+	AddedAt time.Time `bson:"addedAt" json:"addedAt"`
 }
 
 type Group struct {
-	Key        int64     `bson:"_id" json:"key"`
-	AppleApps  []AppInfo `bson:"appleApps" json:"appleApps"`
-	GoogleApps []AppInfo `bson:"googleApps" json:"googleApps"`
+	Key              int64           `bson:"_id" json:"key"`
+	AppleApps        []AppInfo       `bson:"appleApps" json:"appleApps"`
+	GoogleApps       []AppInfo       `bson:"googleApps" json:"googleApps"`
+	AppleDevelopers  []DeveloperInfo `bson:"appleDevelopers" json:"appleDevelopers"`
+	GoogleDevelopers []DeveloperInfo `bson:"googleDevelopers" json:"googleDevelopers"`
+	ReviewRules      []ReviewRule    `bson:"reviewRules" json:"reviewRules"`
+	Charts           []ChartInfo     `bson:"charts" json:"charts"`
+	Digest           string          `bson:"digest,omitempty" json:"digest,omitempty"` // Digest reports sent to the group, empty is DigestOff
+}
+
+// Digest settings of a group, chosen with /digest.
+const (
+	DigestOff     = "off"
+	DigestWeekly  = "weekly"
+	DigestMonthly = "monthly"
+	DigestBoth    = "both"
+)
+
+var DigestSettings = []string{DigestOff, DigestWeekly, DigestMonthly, DigestBoth}
+
+// DigestOrDefault returns the digest setting, DigestOff if unset.
+func (g *Group) DigestOrDefault() string {
+	if g.Digest == "" {
+		return DigestOff
+	}
+	return g.Digest
+}
+
+// WantsDigest reports whether the group gets the weekly or monthly digest.
+func (g *Group) WantsDigest(period string) bool {
+	return g.Digest == period || g.Digest == DigestBoth
 }
 
 func NewGroup(groupID int64) *Group {
 	return &Group{
-		Key:        groupID,
-		AppleApps:  make([]AppInfo, 0),
-		GoogleApps: make([]AppInfo, 0),
+		Key:              groupID,
+		AppleApps:        make([]AppInfo, 0),
+		GoogleApps:       make([]AppInfo, 0),
+		AppleDevelopers:  make([]DeveloperInfo, 0),
+		GoogleDevelopers: make([]DeveloperInfo, 0),
+		ReviewRules:      make([]ReviewRule, 0),
+		Charts:           make([]ChartInfo, 0),
 	}
 }
 
+// AddAppleApp tracks the app in a storefront. Adding a known app in another
+// country adds a storefront to the existing entry.
 func (g *Group) AddAppleApp(appID, country string) bool {
-	for _, app := range g.AppleApps {
-		if app.AppID == appID && app.Country == country {
-			return false // Already exists
-		}
-	}
-	g.AppleApps = append(g.AppleApps, AppInfo{AppID: appID, Country: country})
-	return true
+	return addApp(&g.AppleApps, appID, country)
+}
+
+// RemoveAppleStorefront stops tracking the app in one country and removes the
+// entry once no storefront is left.
+func (g *Group) RemoveAppleStorefront(appID, country string) bool {
+	return removeStorefront(&g.AppleApps, appID, country)
 }
 
 func (g *Group) RemoveAppleApp(appID string) bool {
@@ -39,14 +178,16 @@ func (g *Group) RemoveAppleApp(appID string) bool {
 	return false
 }
 
+// AddGoogleApp tracks the app in a storefront. Adding a known app in another
+// country adds a storefront to the existing entry.
 func (g *Group) AddGoogleApp(appID, country string) bool {
-	for _, app := range g.GoogleApps {
-		if app.AppID == appID && app.Country == country {
-			return false // Already exists
-		}
-	}
-	g.GoogleApps = append(g.GoogleApps, AppInfo{AppID: appID, Country: country})
-	return true
+	return addApp(&g.GoogleApps, appID, country)
+}
+
+// RemoveGoogleStorefront stops tracking the app in one country and removes the
+// entry once no storefront is left.
+func (g *Group) RemoveGoogleStorefront(appID, country string) bool {
+	return removeStorefront(&g.GoogleApps, appID, country)
 }
 
 func (g *Group) RemoveGoogleApp(appID string) bool {
@@ -58,3 +199,222 @@ func (g *Group) RemoveGoogleApp(appID string) bool {
 	}
 	return false
 }
+
+func (g *Group) SetAppleAppMuted(appID string, muted bool) bool {
+	return setMuted(g.AppleApps, appID, muted)
+}
+
+func (g *Group) SetGoogleAppMuted(appID string, muted bool) bool {
+	return setMuted(g.GoogleApps, appID, muted)
+}
+
+func setMuted(apps []AppInfo, appID string, muted bool) bool {
+	return updateApp(apps, appID, func(app *AppInfo) { app.Muted = muted })
+}
+
+func (g *Group) SetAppleAppLabel(appID, label string) bool {
+	return setLabel(g.AppleApps, appID, label)
+}
+
+func (g *Group) SetGoogleAppLabel(appID, label string) bool {
+	return setLabel(g.GoogleApps, appID, label)
+}
+
+func setLabel(apps []AppInfo, appID, label string) bool {
+	return updateApp(apps, appID, func(app *AppInfo) { app.Label = label })
+}
+
+func (g *Group) AddAppleAppTags(appID string, tags []string) bool {
+	return updateApp(g.AppleApps, appID, func(app *AppInfo) { app.addTags(tags) })
+}
+
+func (g *Group) RemoveAppleAppTags(appID string, tags []string) bool {
+	return updateApp(g.AppleApps, appID, func(app *AppInfo) { app.removeTags(tags) })
+}
+
+func (g *Group) AddGoogleAppTags(appID string, tags []string) bool {
+	return updateApp(g.GoogleApps, appID, func(app *AppInfo) { app.addTags(tags) })
+}
+
+func (g *Group) RemoveGoogleAppTags(appID string, tags []string) bool {
+	return updateApp(g.GoogleApps, appID, func(app *AppInfo) { app.removeTags(tags) })
+}
+
+// updateApp applies fn to every entry of the app and reports whether any
+// was found.
+func updateApp(apps []AppInfo, appID string, fn func(*AppInfo)) bool {
+	found := false
+	for i := range apps {
+		if apps[i].AppID == appID {
+			fn(&apps[i])
+			found = true
+		}
+	}
+	return found
+}
+
+func (g *Group) AddAppleDeveloper(developer DeveloperInfo) bool {
+	return addDeveloper(&g.AppleDevelopers, developer)
+}
+
+func (g *Group) RemoveAppleDeveloper(developerID, country string) bool {
+	return removeDeveloper(&g.AppleDevelopers, developerID, country)
+}
+
+func (g *Group) AddGoogleDeveloper(developer DeveloperInfo) bool {
+	return addDeveloper(&g.GoogleDevelopers, developer)
+}
+
+func (g *Group) RemoveGoogleDeveloper(developerID, country string) bool {
+	return removeDeveloper(&g.GoogleDevelopers, developerID, country)
+}
+
+func addDeveloper(developers *[]DeveloperInfo, developer DeveloperInfo) bool {
+	for _, d := range *developers {
+		if d.DeveloperID == developer.DeveloperID && d.Country == developer.Country {
+			return false // Already exists
+		}
+	}
+	*developers = append(*developers, developer)
+	return true
+}
+
+func removeDeveloper(developers *[]DeveloperInfo, developerID, country string) bool {
+	for i, d := range *developers {
+		if d.DeveloperID == developerID && d.Country == country {
+			*developers = append((*developers)[:i], (*developers)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func addApp(apps *[]AppInfo, appID, country string) bool {
+	for i, app := range *apps {
+		if app.AppID != appID {
+			continue
+		}
+		if app.HasStorefront(country) {
+			return false // Already exists
+		}
+		(*apps)[i].Countries = append((*apps)[i].Countries, country)
+		return true
+	}
+	*apps = append(*apps, AppInfo{AppID: appID, Country: country})
+	return true
+}
+
+func removeStorefront(apps *[]AppInfo, appID, country string) bool {
+	for i, app := range *apps {
+		if app.AppID != appID || !app.HasStorefront(country) {
+			continue
+		}
+
+		remaining := make([]string, 0, len(app.Countries))
+		for _, c := range app.Storefronts() {
+			if c != country {
+				remaining = append(remaining, c)
+			}
+		}
+
+		if len(remaining) == 0 {
+			*apps = append((*apps)[:i], (*apps)[i+1:]...)
+			return true
+		}
+
+		// Promote the next storefront when the primary one is removed
+		if country == app.Country {
+			(*apps)[i].NotFoundCount = 0
+		}
+		delete((*apps)[i].NotFoundCounts, country)
+		(*apps)[i].Country = remaining[0]
+		(*apps)[i].Countries = remaining[1:]
+		return true
+	}
+	return false
+}
+
+// MergeDuplicateApps folds entries tracking the same app in different
+// countries, left from before an entry could hold several storefronts, into
+// the first one. It reports whether anything was merged.
+func (g *Group) MergeDuplicateApps() bool {
+	appleMerged := mergeApps(&g.AppleApps)
+	googleMerged := mergeApps(&g.GoogleApps)
+	return appleMerged || googleMerged
+}
+
+func mergeApps(apps *[]AppInfo) bool {
+	merged := make([]AppInfo, 0, len(*apps))
+	index := make(map[string]int, len(*apps))
+	for _, app := range *apps {
+		i, ok := index[app.AppID]
+		if !ok {
+			index[app.AppID] = len(merged)
+			merged = append(merged, app)
+			continue
+		}
+
+		first := &merged[i]
+		for _, country := range app.Storefronts() {
+			if !first.HasStorefront(country) {
+				first.Countries = append(first.Countries, country)
+			}
+		}
+		// Stay in the reports unless every entry was muted
+		first.Muted = first.Muted && app.Muted
+		if first.Label == "" {
+			first.Label = app.Label
+		}
+		first.addTags(app.Tags)
+	}
+
+	if len(merged) == len(*apps) {
+		return false
+	}
+	*apps = merged
+	return true
+}
+
+func (g *Group) AddReviewRule(keyword string, addedBy int64) bool {
+	for _, rule := range g.ReviewRules {
+		if rule.Keyword == keyword {
+			return false // Already exists
+		}
+	}
+	g.ReviewRules = append(g.ReviewRules, ReviewRule{
+		Keyword: keyword,
+		AddedBy: addedBy,
+		AddedAt: time.Now(),
+	})
+	return true
+}
+
+func (g *Group) RemoveReviewRule(keyword string) bool {
+	for i, rule := range g.ReviewRules {
+		if rule.Keyword == keyword {
+			g.ReviewRules = append(g.ReviewRules[:i], g.ReviewRules[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Group) AddChart(chart ChartInfo) bool {
+	for _, c := range g.Charts {
+		if c == chart {
+			return false // Already exists
+		}
+	}
+	g.Charts = append(g.Charts, chart)
+	return true
+}
+
+func (g *Group) RemoveChart(chart ChartInfo) bool {
+	for i, c := range g.Charts {
+		if c == chart {
+			g.Charts = append(g.Charts[:i], g.Charts[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Listing fields watched for changes
+const (
+	FieldTitle         = "title"
+	FieldIcon          = "icon"
+	FieldScreenshots   = "screenshots"
+	FieldDescription   = "description"
+	FieldPrice         = "price"
+	FieldContentRating = "contentRating"
+)
+
+// Price change directions, set as the Kind of a price FieldChange
+const (
+	PriceDrop     = "drop"
+	PriceIncrease = "increase"
+	PriceNowFree  = "nowFree"
+	PriceNowPaid  = "nowPaid"
+)
+
+// FieldChange is one changed listing field. Old and New hold the displayed
+// values, the full text for descriptions.
+type FieldChange struct {
+	Field string `bson:"field" json:"field"`
+	Old   string `bson:"old" json:"old"`
+	New   string `bson:"new" json:"new"`
+	Kind  string `bson:"kind,omitempty" json:"kind,omitempty"` // Price direction, empty when not comparable
+}
+
+// MetadataChange is a listing change detected between two scrapes, kept
+// until it is posted to the groups tracking the app.
+type MetadataChange struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Store      string             `bson:"store" json:"store"`
+	AppID      string             `bson:"appId" json:"appId"`
+	Country    string             `bson:"country" json:"country"`
+	Title      string             `bson:"title" json:"title"`
+	Changes    []FieldChange      `bson:"changes" json:"changes"`
+	DetectedAt time.Time          `bson:"detectedAt" json:"detectedAt"`
+	Notified   bool               `bson:"notified" json:"notified"`
+}
+
+// DiffListing compares the store listing with an earlier snapshot. Snapshots
+// without a recorded listing are not compared.
+func (s *AppSnapshot) DiffListing(prev *AppSnapshot) []FieldChange {
+	if prev == nil || !prev.HasListing() || !s.HasListing() {
+		return nil
+	}
+
+	var changes []FieldChange
+	add := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, FieldChange{Field: field, Old: old, New: new})
+		}
+	}
+
+	add(FieldTitle, prev.Title, s.Title)
+	add(FieldIcon, prev.Icon, s.Icon)
+	if !slices.Equal(prev.Screenshots, s.Screenshots) {
+		changes = append(changes, FieldChange{
+			Field: FieldScreenshots,
+			Old:   fmt.Sprintf("%d", len(prev.Screenshots)),
+			New:   fmt.Sprintf("%d", len(s.Screenshots)),
+		})
+	}
+	add(FieldDescription, prev.Description, s.Description)
+	if prev.PriceText() != s.PriceText() {
+		changes = append(changes, FieldChange{
+			Field: FieldPrice,
+			Old:   prev.PriceText(),
+			New:   s.PriceText(),
+			Kind:  priceDirection(prev, s),
+		})
+	}
+	add(FieldContentRating, prev.ContentRating, s.ContentRating)
+
+	return changes
+}
+
+// HasListing reports whether the snapshot recorded the store listing.
+// Snapshots taken before listings were recorded have no icon.
+func (s *AppSnapshot) HasListing() bool {
+	return s.Icon != ""
+}
+
+func (s *AppSnapshot) IsFree() bool {
+	return s.Free || s.Price == 0
+}
+
+// PriceText renders the price, e.g. "Free" or "0.99 USD".
+func (s *AppSnapshot) PriceText() string {
+	if s.IsFree() {
+		return "Free"
+	}
+	return fmt.Sprintf("%.2f %s", s.Price, s.Currency)
+}
+
+// priceDirection classifies a price change. Prices in different currencies
+// are not compared.
+func priceDirection(prev, cur *AppSnapshot) string {
+	switch {
+	case !prev.IsFree() && cur.IsFree():
+		return PriceNowFree
+	case prev.IsFree() && !cur.IsFree():
+		return PriceNowPaid
+	case prev.Currency != cur.Currency:
+		return ""
+	case cur.Price < prev.Price:
+		return PriceDrop
+	default:
+		return PriceIncrease
+	}
+}
+
+func NewMetadataChange(snapshot *AppSnapshot, changes []FieldChange) *MetadataChange {
+	return &MetadataChange{
+		Store:      snapshot.Store,
+		AppID:      snapshot.AppID,
+		Country:    snapshot.Country,
+		Title:      snapshot.Title,
+		Changes:    changes,
+		DetectedAt: time.Now(),
+	}
+}
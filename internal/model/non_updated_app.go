@@ -9,4 +9,5 @@ type NonUpdatedApp struct {
 	Reviews interface{} // Can be int or string
 	Ratings int64
 	IsApple bool
+	Trend   Trend
 }
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// AppleReviewResponse is one entry of the Apple reviews endpoint.
+type AppleReviewResponse struct {
+	ID       string `json:"id"`
+	UserName string `json:"userName"`
+	Version  string `json:"version"`
+	Score    int    `json:"score"`
+	Title    string `json:"title"`
+	Text     string `json:"text"`
+	Updated  string `json:"updated"`
+}
+
+// GoogleReviewsResponse is a page of the Google reviews endpoint.
+type GoogleReviewsResponse struct {
+	Data []GoogleReviewResponse `json:"data"`
+}
+
+type GoogleReviewResponse struct {
+	ID       string `json:"id"`
+	UserName string `json:"userName"`
+	Version  string `json:"version"`
+	Score    int    `json:"score"`
+	Title    string `json:"title"`
+	Text     string `json:"text"`
+	Date     string `json:"date"`
+}
+
+// Review is a stored user review, unique per store and review ID.
+type Review struct {
+	Key       string    `bson:"_id" json:"key"`
+	Store     string    `bson:"store" json:"store"`
+	AppID     string    `bson:"appId" json:"appId"`
+	Country   string    `bson:"country" json:"country"`
+	ReviewID  string    `bson:"reviewId" json:"reviewId"`
+	UserName  string    `bson:"userName" json:"userName"`
+	Score     int       `bson:"score" json:"score"`
+	Title     string    `bson:"title" json:"title"`
+	Text      string    `bson:"text" json:"text"`
+	Version   string    `bson:"version" json:"version"`
+	Date      time.Time `bson:"date" json:"date"`
+	FetchedAt time.Time `bson:"fetchedAt" json:"fetchedAt"`
+}
+
+func newReview(store, appID, country, reviewID string) Review {
+	return Review{
+		Key:       fmt.Sprintf("%s:%s:%s", store, appID, reviewID),
+		Store:     store,
+		AppID:     appID,
+		Country:   country,
+		ReviewID:  reviewID,
+		FetchedAt: time.Now(),
+	}
+}
+
+func NewAppleReview(appID, country string, r AppleReviewResponse) Review {
+	review := newReview(StoreApple, appID, country, r.ID)
+	review.UserName = r.UserName
+	review.Score = r.Score
+	review.Title = r.Title
+	review.Text = r.Text
+	review.Version = r.Version
+	review.Date, _ = time.Parse(time.RFC3339, r.Updated)
+	return review
+}
+
+func NewGoogleReview(appID, country string, r GoogleReviewResponse) Review {
+	review := newReview(StoreGoogle, appID, country, r.ID)
+	review.UserName = r.UserName
+	review.Score = r.Score
+	review.Title = r.Title
+	review.Text = r.Text
+	review.Version = r.Version
+	review.Date, _ = time.Parse(time.RFC3339, r.Date)
+	return review
+}
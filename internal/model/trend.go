@@ -0,0 +1,27 @@
+package model
+
+import "fmt"
+
+// Rate is a derived metric that may be unknown, e.g. when the snapshot
+// history is too short.
+type Rate struct {
+	Value float64
+	OK    bool
+}
+
+// Format renders the rate with the given verb, or "-" when unknown.
+func (r Rate) Format(format string) string {
+	if !r.OK {
+		return "-"
+	}
+	return fmt.Sprintf(format, r.Value)
+}
+
+// Trend is the growth of an app computed from its snapshot history.
+type Trend struct {
+	RatingsPerDay7  Rate
+	RatingsPerDay30 Rate
+	ReviewsPerDay7  Rate
+	ReviewsPerDay30 Rate
+	ScoreDelta30    Rate // Score change over the last 30 days
+}
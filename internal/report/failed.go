@@ -0,0 +1,36 @@
+// Package report holds report sections shared by the scheduled checks and the
+// commands that run them on demand.
+package report
+
+import (
+	"fmt"
+
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+)
+
+// FailedSection lists apps that could not be checked, so a short report is
+// never mistaken for "everything is fine".
+func FailedSection(failedApps []model.FailedApp) string {
+	if len(failedApps) == 0 {
+		return ""
+	}
+
+	var rows [][]string
+	for _, app := range failedApps {
+		store := "Google"
+		if app.IsApple {
+			store = "Apple"
+		}
+
+		rows = append(rows, []string{
+			util.TruncateString(app.AppID, 30),
+			store,
+			app.Country,
+			app.Error,
+		})
+	}
+
+	headers := []string{"App", "Store", "Country", "Error"}
+	return fmt.Sprintf("\n\n*Could not check: %d*\n\n%s", len(failedApps), util.BuildTable(headers, rows))
+}
@@ -98,3 +98,47 @@ func (r *AdminRepository) GetAllGroups() ([]int64, error) {
 
 	return admin.Groups, nil
 }
+
+func (r *AdminRepository) AddAdmin(userID, addedBy int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	admin, err := r.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !admin.AddAdmin(userID, addedBy) {
+		return fmt.Errorf("admin already exists")
+	}
+
+	return r.Save(ctx, admin)
+}
+
+func (r *AdminRepository) RemoveAdmin(userID int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	admin, err := r.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !admin.RemoveAdmin(userID) {
+		return fmt.Errorf("admin not found")
+	}
+
+	return r.Save(ctx, admin)
+}
+
+func (r *AdminRepository) GetAllAdmins() ([]model.AdminUser, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	admin, err := r.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return admin.Admins, nil
+}
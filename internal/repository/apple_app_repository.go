@@ -22,9 +22,9 @@ func NewAppleAppRepository() *AppleAppRepository {
 	}
 }
 
-func (r *AppleAppRepository) Get(ctx context.Context, appID string) (*model.AppleApp, error) {
+func (r *AppleAppRepository) Get(ctx context.Context, key string) (*model.AppleApp, error) {
 	app := &model.AppleApp{}
-	err := r.collection.FindOne(ctx, bson.M{"_id": appID}).Decode(app)
+	err := r.collection.FindOne(ctx, bson.M{"_id": key}).Decode(app)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil // Not found
@@ -44,11 +44,11 @@ func (r *AppleAppRepository) Save(ctx context.Context, app *model.AppleApp) erro
 	return nil
 }
 
-func (r *AppleAppRepository) GetCached(appID string) (*model.AppleApp, error) {
+func (r *AppleAppRepository) GetCached(appID, country string) (*model.AppleApp, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	app, err := r.Get(ctx, appID)
+	app, err := r.Get(ctx, model.AppKey(appID, country))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ChangeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewChangeRepository() *ChangeRepository {
+	return &ChangeRepository{
+		collection: GetCollection("metadata_change"),
+	}
+}
+
+func (r *ChangeRepository) Insert(ctx context.Context, change *model.MetadataChange) error {
+	_, err := r.collection.InsertOne(ctx, change)
+	if err != nil {
+		return fmt.Errorf("failed to insert metadata change: %w", err)
+	}
+	return nil
+}
+
+// GetPending returns the changes not posted yet, oldest first.
+func (r *ChangeRepository) GetPending() ([]model.MetadataChange, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "detectedAt", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"notified": false}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find metadata changes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	changes := make([]model.MetadataChange, 0)
+	if err := cursor.All(ctx, &changes); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata changes: %w", err)
+	}
+	return changes, nil
+}
+
+func (r *ChangeRepository) MarkNotified(ids []primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": ids}},
+		bson.M{"$set": bson.M{"notified": true}})
+	if err != nil {
+		return fmt.Errorf("failed to mark metadata changes notified: %w", err)
+	}
+	return nil
+}
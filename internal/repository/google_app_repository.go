@@ -22,9 +22,9 @@ func NewGoogleAppRepository() *GoogleAppRepository {
 	}
 }
 
-func (r *GoogleAppRepository) Get(ctx context.Context, appID string) (*model.GoogleApp, error) {
+func (r *GoogleAppRepository) Get(ctx context.Context, key string) (*model.GoogleApp, error) {
 	app := &model.GoogleApp{}
-	err := r.collection.FindOne(ctx, bson.M{"_id": appID}).Decode(app)
+	err := r.collection.FindOne(ctx, bson.M{"_id": key}).Decode(app)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil // Not found
@@ -44,11 +44,11 @@ func (r *GoogleAppRepository) Save(ctx context.Context, app *model.GoogleApp) er
 	return nil
 }
 
-func (r *GoogleAppRepository) GetCached(appID string) (*model.GoogleApp, error) {
+func (r *GoogleAppRepository) GetCached(appID, country string) (*model.GoogleApp, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	app, err := r.Get(ctx, appID)
+	app, err := r.Get(ctx, model.AppKey(appID, country))
 	if err != nil {
 		return nil, err
 	}
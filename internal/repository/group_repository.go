@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/miti99/store-scraper-bot-go/internal/model"
@@ -43,6 +44,36 @@ func (r *GroupRepository) Save(ctx context.Context, group *model.Group) error {
 	return nil
 }
 
+// MergeDuplicateApps merges entries tracking the same app once per country
+// in every group. It runs once at startup and returns the groups changed.
+func (r *GroupRepository) MergeDuplicateApps() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to find groups: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	groups := make([]model.Group, 0)
+	if err := cursor.All(ctx, &groups); err != nil {
+		return 0, fmt.Errorf("failed to decode groups: %w", err)
+	}
+
+	merged := 0
+	for i := range groups {
+		if !groups[i].MergeDuplicateApps() {
+			continue
+		}
+		if err := r.Save(ctx, &groups[i]); err != nil {
+			return merged, err
+		}
+		merged++
+	}
+	return merged, nil
+}
+
 func (r *GroupRepository) Delete(ctx context.Context, groupID int64) error {
 	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": groupID})
 	if err != nil {
@@ -51,17 +82,36 @@ func (r *GroupRepository) Delete(ctx context.Context, groupID int64) error {
 	return nil
 }
 
-func (r *GroupRepository) AddAppleApp(groupID int64, appID, country string) error {
+// AddAppleApp tracks the app in the group. It returns the app's primary
+// country, which differs from country when the app was tracked already and
+// country became an additional storefront.
+func (r *GroupRepository) AddAppleApp(groupID int64, appID, country string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	group, err := r.Get(ctx, groupID)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if !group.AddAppleApp(appID, country) {
-		return fmt.Errorf("apple app already exists in group")
+		return "", fmt.Errorf("apple app already exists in group for this country")
+	}
+
+	return primaryCountry(group.AppleApps, appID), r.Save(ctx, group)
+}
+
+func (r *GroupRepository) RemoveAppleStorefront(groupID int64, appID, country string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.RemoveAppleStorefront(appID, country) {
+		return fmt.Errorf("apple app not found in group for country %s", country)
 	}
 
 	return r.Save(ctx, group)
@@ -83,17 +133,33 @@ func (r *GroupRepository) RemoveAppleApp(groupID int64, appID string) error {
 	return r.Save(ctx, group)
 }
 
-func (r *GroupRepository) AddGoogleApp(groupID int64, appID, country string) error {
+func (r *GroupRepository) AddGoogleApp(groupID int64, appID, country string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	group, err := r.Get(ctx, groupID)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if !group.AddGoogleApp(appID, country) {
-		return fmt.Errorf("google app already exists in group")
+		return "", fmt.Errorf("google app already exists in group for this country")
+	}
+
+	return primaryCountry(group.GoogleApps, appID), r.Save(ctx, group)
+}
+
+func (r *GroupRepository) RemoveGoogleStorefront(groupID int64, appID, country string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.RemoveGoogleStorefront(appID, country) {
+		return fmt.Errorf("google app not found in group for country %s", country)
 	}
 
 	return r.Save(ctx, group)
@@ -114,3 +180,400 @@ func (r *GroupRepository) RemoveGoogleApp(groupID int64, appID string) error {
 
 	return r.Save(ctx, group)
 }
+
+func (r *GroupRepository) SetAppleAppMuted(groupID int64, appID string, muted bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.SetAppleAppMuted(appID, muted) {
+		return fmt.Errorf("apple app not found in group")
+	}
+
+	return r.Save(ctx, group)
+}
+
+func (r *GroupRepository) SetGoogleAppMuted(groupID int64, appID string, muted bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.SetGoogleAppMuted(appID, muted) {
+		return fmt.Errorf("google app not found in group")
+	}
+
+	return r.Save(ctx, group)
+}
+
+func (r *GroupRepository) SetAppleAppLabel(groupID int64, appID, label string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.SetAppleAppLabel(appID, label) {
+		return fmt.Errorf("apple app not found in group")
+	}
+
+	return r.Save(ctx, group)
+}
+
+func (r *GroupRepository) SetGoogleAppLabel(groupID int64, appID, label string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.SetGoogleAppLabel(appID, label) {
+		return fmt.Errorf("google app not found in group")
+	}
+
+	return r.Save(ctx, group)
+}
+
+func (r *GroupRepository) AddAppleAppTags(groupID int64, appID string, tags []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.AddAppleAppTags(appID, tags) {
+		return fmt.Errorf("apple app not found in group")
+	}
+
+	return r.Save(ctx, group)
+}
+
+func (r *GroupRepository) RemoveAppleAppTags(groupID int64, appID string, tags []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.RemoveAppleAppTags(appID, tags) {
+		return fmt.Errorf("apple app not found in group")
+	}
+
+	return r.Save(ctx, group)
+}
+
+func (r *GroupRepository) AddGoogleAppTags(groupID int64, appID string, tags []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.AddGoogleAppTags(appID, tags) {
+		return fmt.Errorf("google app not found in group")
+	}
+
+	return r.Save(ctx, group)
+}
+
+func (r *GroupRepository) RemoveGoogleAppTags(groupID int64, appID string, tags []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.RemoveGoogleAppTags(appID, tags) {
+		return fmt.Errorf("google app not found in group")
+	}
+
+	return r.Save(ctx, group)
+}
+
+// AddAppleDeveloper subscribes the group to a developer and adds the listed
+// apps. It returns the app IDs that were not tracked yet.
+func (r *GroupRepository) AddAppleDeveloper(groupID int64, developer model.DeveloperInfo) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !group.AddAppleDeveloper(developer) {
+		return nil, fmt.Errorf("apple developer already exists in group")
+	}
+
+	added := make([]string, 0)
+	for _, appID := range developer.AppIDs {
+		if group.AddAppleApp(appID, developer.Country) {
+			added = append(added, appID)
+		}
+	}
+
+	return added, r.Save(ctx, group)
+}
+
+func (r *GroupRepository) RemoveAppleDeveloper(groupID int64, developerID, country string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.RemoveAppleDeveloper(developerID, country) {
+		return fmt.Errorf("apple developer not found in group")
+	}
+
+	return r.Save(ctx, group)
+}
+
+// AddGoogleDeveloper subscribes the group to a developer and adds the listed
+// apps. It returns the app IDs that were not tracked yet.
+func (r *GroupRepository) AddGoogleDeveloper(groupID int64, developer model.DeveloperInfo) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !group.AddGoogleDeveloper(developer) {
+		return nil, fmt.Errorf("google developer already exists in group")
+	}
+
+	added := make([]string, 0)
+	for _, appID := range developer.AppIDs {
+		if group.AddGoogleApp(appID, developer.Country) {
+			added = append(added, appID)
+		}
+	}
+
+	return added, r.Save(ctx, group)
+}
+
+func (r *GroupRepository) RemoveGoogleDeveloper(groupID int64, developerID, country string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.RemoveGoogleDeveloper(developerID, country) {
+		return fmt.Errorf("google developer not found in group")
+	}
+
+	return r.Save(ctx, group)
+}
+
+// SetAppleAppNotFoundCount updates the count of one storefront in place, so a
+// long running check does not overwrite changes made to the group meanwhile.
+func (r *GroupRepository) SetAppleAppNotFoundCount(groupID int64, appID, country string, count int) error {
+	return r.setAppNotFoundCount(groupID, "appleApps", appID, country, count)
+}
+
+func (r *GroupRepository) SetGoogleAppNotFoundCount(groupID int64, appID, country string, count int) error {
+	return r.setAppNotFoundCount(groupID, "googleApps", appID, country, count)
+}
+
+func (r *GroupRepository) setAppNotFoundCount(groupID int64, field, appID, country string, count int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The country becomes part of a field path
+	if country == "" || strings.ContainsAny(country, ".$") {
+		return fmt.Errorf("invalid country: %q", country)
+	}
+
+	filter := bson.M{
+		"_id": groupID,
+		field: bson.M{"$elemMatch": bson.M{
+			"appId": appID,
+			"$or":   bson.A{bson.M{"country": country}, bson.M{"countries": country}},
+		}},
+	}
+	update := bson.M{"$set": bson.M{field + ".$.notFoundCounts." + country: count}}
+
+	if _, err := r.collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to update not found count: %w", err)
+	}
+	return nil
+}
+
+// SetAppleDeveloperAppIDs updates the apps seen for a developer in place, like
+// SetAppleAppNotFoundCount.
+func (r *GroupRepository) SetAppleDeveloperAppIDs(groupID int64, developerID, country string, appIDs []string) error {
+	return r.setDeveloperAppIDs(groupID, "appleDevelopers", developerID, country, appIDs)
+}
+
+func (r *GroupRepository) SetGoogleDeveloperAppIDs(groupID int64, developerID, country string, appIDs []string) error {
+	return r.setDeveloperAppIDs(groupID, "googleDevelopers", developerID, country, appIDs)
+}
+
+func (r *GroupRepository) setDeveloperAppIDs(groupID int64, field, developerID, country string, appIDs []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"_id": groupID,
+		field: bson.M{"$elemMatch": bson.M{"developerId": developerID, "country": country}},
+	}
+	update := bson.M{"$set": bson.M{field + ".$.appIds": appIDs}}
+
+	if _, err := r.collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to update developer apps: %w", err)
+	}
+	return nil
+}
+
+// TrackAppleApp adds the app or the storefront in place and reports whether
+// anything was added. Unlike AddAppleApp it does not rewrite the group.
+func (r *GroupRepository) TrackAppleApp(groupID int64, appID, country string) (bool, error) {
+	return r.trackApp(groupID, "appleApps", appID, country)
+}
+
+func (r *GroupRepository) TrackGoogleApp(groupID int64, appID, country string) (bool, error) {
+	return r.trackApp(groupID, "googleApps", appID, country)
+}
+
+func (r *GroupRepository) trackApp(groupID int64, field, appID, country string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Push a new entry unless the app is tracked already
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": groupID, field + ".appId": bson.M{"$ne": appID}},
+		bson.M{"$push": bson.M{field: model.AppInfo{AppID: appID, Country: country}}})
+	if err != nil {
+		return false, fmt.Errorf("failed to track app: %w", err)
+	}
+	if result.ModifiedCount > 0 {
+		return true, nil
+	}
+
+	// Otherwise add the storefront to the existing entry
+	filter := bson.M{
+		"_id": groupID,
+		field: bson.M{"$elemMatch": bson.M{
+			"appId":     appID,
+			"country":   bson.M{"$ne": country},
+			"countries": bson.M{"$ne": country},
+		}},
+	}
+	result, err = r.collection.UpdateOne(ctx, filter, bson.M{"$addToSet": bson.M{field + ".$.countries": country}})
+	if err != nil {
+		return false, fmt.Errorf("failed to track app storefront: %w", err)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *GroupRepository) AddReviewRule(groupID int64, keyword string, addedBy int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.AddReviewRule(keyword, addedBy) {
+		return fmt.Errorf("rule already exists in group")
+	}
+
+	return r.Save(ctx, group)
+}
+
+func (r *GroupRepository) RemoveReviewRule(groupID int64, keyword string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.RemoveReviewRule(keyword) {
+		return fmt.Errorf("rule not found in group")
+	}
+
+	return r.Save(ctx, group)
+}
+
+func (r *GroupRepository) SetDigest(groupID int64, digest string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	group.Digest = digest
+	return r.Save(ctx, group)
+}
+
+func (r *GroupRepository) AddChart(groupID int64, chart model.ChartInfo) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.AddChart(chart) {
+		return fmt.Errorf("chart already exists in group")
+	}
+
+	return r.Save(ctx, group)
+}
+
+func (r *GroupRepository) RemoveChart(groupID int64, chart model.ChartInfo) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	group, err := r.Get(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if !group.RemoveChart(chart) {
+		return fmt.Errorf("chart not found in group")
+	}
+
+	return r.Save(ctx, group)
+}
+
+func primaryCountry(apps []model.AppInfo, appID string) string {
+	for _, app := range apps {
+		if app.AppID == appID {
+			return app.Country
+		}
+	}
+	return ""
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
@@ -38,6 +39,49 @@ func InitMongoDB(cfg *config.Config) error {
 		zap.String("database", cfg.MongoDatabase),
 		zap.String("uri", cfg.MongoURI))
 
+	// Queries still work without the indexes, only slower, so do not fail
+	if err := ensureIndexes(); err != nil {
+		cfg.Logger.Warn("Failed to create MongoDB indexes", zap.Error(err))
+	}
+
+	return nil
+}
+
+// indexes are the compound indexes of the history collections, matching the
+// per-app queries and their sort order.
+var indexes = map[string][]bson.D{
+	"app_snapshot": {
+		{{Key: "store", Value: 1}, {Key: "appId", Value: 1}, {Key: "country", Value: 1}, {Key: "capturedAt", Value: -1}},
+		{{Key: "store", Value: 1}, {Key: "appId", Value: 1}, {Key: "country", Value: 1}, {Key: "date", Value: -1}},
+	},
+	"review": {
+		{{Key: "store", Value: 1}, {Key: "appId", Value: 1}, {Key: "country", Value: 1}, {Key: "date", Value: -1}},
+	},
+	"audit": {
+		{{Key: "chatId", Value: 1}, {Key: "createdAt", Value: -1}},
+	},
+	"metadata_change": {
+		{{Key: "notified", Value: 1}, {Key: "detectedAt", Value: 1}},
+	},
+	"chart_ranking": {
+		{{Key: "chartKey", Value: 1}, {Key: "date", Value: -1}},
+	},
+}
+
+// ensureIndexes creates the indexes, which is a no-op for existing ones.
+func ensureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	for name, keys := range indexes {
+		models := make([]mongo.IndexModel, 0, len(keys))
+		for _, key := range keys {
+			models = append(models, mongo.IndexModel{Keys: key})
+		}
+		if _, err := GetCollection(name).Indexes().CreateMany(ctx, models); err != nil {
+			return fmt.Errorf("failed to create indexes for %s: %w", name, err)
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RankingRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRankingRepository() *RankingRepository {
+	return &RankingRepository{
+		collection: GetCollection("chart_ranking"),
+	}
+}
+
+func (r *RankingRepository) Save(ctx context.Context, ranking *model.ChartRanking) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": ranking.Key}, ranking, opts)
+	if err != nil {
+		return fmt.Errorf("failed to save chart ranking: %w", err)
+	}
+	return nil
+}
+
+// GetRecent returns the latest daily rankings of the chart, newest first.
+func (r *RankingRepository) GetRecent(chart model.ChartInfo, limit int) ([]model.ChartRanking, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"chartKey": chart.Key()}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "date", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find chart rankings: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	rankings := make([]model.ChartRanking, 0)
+	if err := cursor.All(ctx, &rankings); err != nil {
+		return nil, fmt.Errorf("failed to decode chart rankings: %w", err)
+	}
+	return rankings, nil
+}
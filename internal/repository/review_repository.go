@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReviewRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReviewRepository() *ReviewRepository {
+	return &ReviewRepository{
+		collection: GetCollection("review"),
+	}
+}
+
+// SaveNew stores the reviews not seen before and returns them. Known reviews
+// are left untouched.
+func (r *ReviewRepository) SaveNew(ctx context.Context, reviews []model.Review) ([]model.Review, error) {
+	added := make([]model.Review, 0)
+	opts := options.Update().SetUpsert(true)
+
+	for _, review := range reviews {
+		result, err := r.collection.UpdateOne(ctx,
+			bson.M{"_id": review.Key},
+			bson.M{"$setOnInsert": review},
+			opts)
+		if err != nil {
+			return added, fmt.Errorf("failed to save review: %w", err)
+		}
+		if result.UpsertedCount > 0 {
+			added = append(added, review)
+		}
+	}
+
+	return added, nil
+}
+
+// HasAny reports whether any review of the app was stored before.
+func (r *ReviewRepository) HasAny(ctx context.Context, store, appID, country string) (bool, error) {
+	filter := bson.M{"store": store, "appId": appID, "country": country}
+	count, err := r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to count reviews: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetRecent returns the newest stored reviews of an app.
+func (r *ReviewRepository) GetRecent(store, appID, country string, limit int) ([]model.Review, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"store": store, "appId": appID, "country": country}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "date", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reviews: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	reviews := make([]model.Review, 0)
+	if err := cursor.All(ctx, &reviews); err != nil {
+		return nil, fmt.Errorf("failed to decode reviews: %w", err)
+	}
+	return reviews, nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SnapshotRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSnapshotRepository() *SnapshotRepository {
+	return &SnapshotRepository{
+		collection: GetCollection("app_snapshot"),
+	}
+}
+
+func (r *SnapshotRepository) Save(ctx context.Context, snapshot *model.AppSnapshot) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": snapshot.Key}, snapshot, opts)
+	if err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetLatest returns the most recent snapshot of an app, or nil if there is
+// none.
+func (r *SnapshotRepository) GetLatest(ctx context.Context, store, appID, country string) (*model.AppSnapshot, error) {
+	filter := bson.M{"store": store, "appId": appID, "country": country}
+	opts := options.FindOne().SetSort(bson.D{{Key: "capturedAt", Value: -1}})
+
+	snapshot := &model.AppSnapshot{}
+	err := r.collection.FindOne(ctx, filter, opts).Decode(snapshot)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// GetHistory returns the snapshots of an app since the given time, oldest first.
+func (r *SnapshotRepository) GetHistory(store, appID, country string, since time.Time) ([]model.AppSnapshot, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"store":      store,
+		"appId":      appID,
+		"country":    country,
+		"capturedAt": bson.M{"$gte": since},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "capturedAt", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find snapshots: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	snapshots := make([]model.AppSnapshot, 0)
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshots: %w", err)
+	}
+	return snapshots, nil
+}
+
+// GetLatestBefore returns the last snapshot of an app taken before the given
+// date (YYYY-MM-DD), or nil if there is none.
+func (r *SnapshotRepository) GetLatestBefore(store, appID, country, date string) (*model.AppSnapshot, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"store":   store,
+		"appId":   appID,
+		"country": country,
+		"date":    bson.M{"$lt": date},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "date", Value: -1}})
+
+	snapshot := &model.AppSnapshot{}
+	err := r.collection.FindOne(ctx, filter, opts).Decode(snapshot)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// GetVersions returns the last released versions of an app, newest first,
+// with the release notes of the last snapshot of each version.
+func (r *SnapshotRepository) GetVersions(store, appID, country string, limit int) ([]model.VersionRecord, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"store": store, "appId": appID, "country": country, "version": bson.M{"$ne": ""}}}},
+		{{Key: "$sort", Value: bson.M{"capturedAt": 1}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":          "$version",
+			"releaseNotes": bson.M{"$last": "$releaseNotes"},
+			"updated":      bson.M{"$last": "$updated"},
+			"firstSeen":    bson.M{"$first": "$capturedAt"},
+		}}},
+		{{Key: "$sort", Value: bson.M{"firstSeen": -1}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate versions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	versions := make([]model.VersionRecord, 0)
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, fmt.Errorf("failed to decode versions: %w", err)
+	}
+	return versions, nil
+}
@@ -0,0 +1,155 @@
+// Package review collects user reviews from the stores.
+package review
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
+	"github.com/miti99/store-scraper-bot-go/internal/api/google"
+	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// maxTextLength caps review text in messages.
+const maxTextLength = 300
+
+type Collector struct {
+	reviewRepo    *repository.ReviewRepository
+	appleScraper  *apple.AppleScraper
+	googleScraper *google.GoogleScraper
+	logger        *zap.Logger
+}
+
+func NewCollector(
+	reviewRepo *repository.ReviewRepository,
+	appleScraper *apple.AppleScraper,
+	googleScraper *google.GoogleScraper,
+	cfg *config.Config,
+) *Collector {
+	return &Collector{
+		reviewRepo:    reviewRepo,
+		appleScraper:  appleScraper,
+		googleScraper: googleScraper,
+		logger:        cfg.Logger,
+	}
+}
+
+// Collect fetches the recent reviews of an app, stores them and returns the
+// ones not seen before, oldest first. The first collection of an app only
+// seeds the store, so tracking a new app does not flood the group.
+func (c *Collector) Collect(store, appID, country string) ([]model.Review, error) {
+	reviews, err := c.fetch(store, appID, country)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	seen, err := c.reviewRepo.HasAny(ctx, store, appID, country)
+	if err != nil {
+		return nil, err
+	}
+
+	added, err := c.reviewRepo.SaveNew(ctx, reviews)
+	if err != nil {
+		return nil, err
+	}
+
+	if !seen {
+		c.logger.Info("Seeded reviews",
+			zap.String("store", store),
+			zap.String("appId", appID),
+			zap.String("country", country),
+			zap.Int("reviews", len(added)))
+		return nil, nil
+	}
+
+	sort.SliceStable(added, func(i, j int) bool { return added[i].Date.Before(added[j].Date) })
+	return added, nil
+}
+
+// FetchRecent fetches the newest reviews of an app from the store without
+// storing them, so the scheduled check still sees them as new and alerts.
+func (c *Collector) FetchRecent(store, appID, country string, limit int) ([]model.Review, error) {
+	reviews, err := c.fetch(store, appID, country)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(reviews, func(i, j int) bool { return reviews[i].Date.After(reviews[j].Date) })
+	if len(reviews) > limit {
+		reviews = reviews[:limit]
+	}
+	return reviews, nil
+}
+
+// GetRecent returns the newest stored reviews of an app.
+func (c *Collector) GetRecent(store, appID, country string, limit int) ([]model.Review, error) {
+	return c.reviewRepo.GetRecent(store, appID, country, limit)
+}
+
+func (c *Collector) fetch(store, appID, country string) ([]model.Review, error) {
+	reviews := make([]model.Review, 0)
+
+	if store == model.StoreApple {
+		responses, err := c.appleScraper.GetReviews(appID, country)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range responses {
+			if r.ID != "" {
+				reviews = append(reviews, model.NewAppleReview(appID, country, r))
+			}
+		}
+		return reviews, nil
+	}
+
+	responses, err := c.googleScraper.GetReviews(appID, country)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range responses {
+		if r.ID != "" {
+			reviews = append(reviews, model.NewGoogleReview(appID, country, r))
+		}
+	}
+	return reviews, nil
+}
+
+// Format renders a review for a Telegram message. User text is escaped and
+// kept outside of entities, which legacy Markdown cannot escape within.
+func Format(review model.Review) string {
+	return format(review, util.EscapeMarkdown)
+}
+
+// format renders a review with render applied to the title and text.
+func format(review model.Review, render func(string) string) string {
+	var sb strings.Builder
+	sb.WriteString(Stars(review.Score))
+	if review.Title != "" {
+		sb.WriteString(" " + render(review.Title))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(render(util.TruncateString(review.Text, maxTextLength)))
+	sb.WriteString("\n— " + util.EscapeMarkdown(review.UserName))
+	if review.Version != "" {
+		sb.WriteString(", v" + util.EscapeMarkdown(review.Version))
+	}
+	if !review.Date.IsZero() {
+		sb.WriteString(", " + review.Date.Format("2006-01-02"))
+	}
+	return sb.String()
+}
+
+// Stars renders a 1 to 5 score, e.g. "★★☆☆☆".
+func Stars(score int) string {
+	score = max(0, min(score, 5))
+	return strings.Repeat("★", score) + strings.Repeat("☆", 5-score)
+}
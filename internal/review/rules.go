@@ -0,0 +1,87 @@
+package review
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+)
+
+// Match returns the rule keywords the review's title or text mentions as
+// whole words, ignoring case, so "ads" does not match "downloads".
+func Match(rules []model.ReviewRule, review model.Review) []string {
+	var matched []string
+	for _, rule := range rules {
+		pattern := keywordPattern([]string{rule.Keyword})
+		if len(findKeywords(review.Title, pattern)) > 0 || len(findKeywords(review.Text, pattern)) > 0 {
+			matched = append(matched, rule.Keyword)
+		}
+	}
+	return matched
+}
+
+// FormatHighlighted renders a review like Format with the keywords in bold.
+func FormatHighlighted(review model.Review, keywords []string) string {
+	if len(keywords) == 0 {
+		return Format(review)
+	}
+
+	pattern := keywordPattern(keywords)
+	return format(review, func(text string) string { return highlight(text, pattern) })
+}
+
+func keywordPattern(keywords []string) *regexp.Regexp {
+	quoted := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		quoted[i] = regexp.QuoteMeta(keyword)
+	}
+	return regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+}
+
+// findKeywords returns the matches of pattern that are whole words. Go's \b
+// only knows ASCII letters, so the neighbouring runes are checked instead,
+// which also works for e.g. Vietnamese reviews.
+func findKeywords(text string, pattern *regexp.Regexp) [][]int {
+	var found [][]int
+	for _, loc := range pattern.FindAllStringIndex(text, -1) {
+		first, _ := utf8.DecodeRuneInString(text[loc[0]:])
+		last, _ := utf8.DecodeLastRuneInString(text[:loc[1]])
+		before, _ := utf8.DecodeLastRuneInString(text[:loc[0]])
+		after, _ := utf8.DecodeRuneInString(text[loc[1]:])
+		if isWordRune(first) && loc[0] > 0 && isWordRune(before) {
+			continue
+		}
+		if isWordRune(last) && loc[1] < len(text) && isWordRune(after) {
+			continue
+		}
+		found = append(found, loc)
+	}
+	return found
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// highlight escapes text and wraps matches in bold. Markdown characters are
+// dropped from the matches, as they cannot be escaped inside an entity.
+func highlight(text string, pattern *regexp.Regexp) string {
+	var sb strings.Builder
+	last := 0
+	for _, loc := range findKeywords(text, pattern) {
+		sb.WriteString(util.EscapeMarkdown(text[last:loc[0]]))
+		sb.WriteString("*" + stripMarkdown(text[loc[0]:loc[1]]) + "*")
+		last = loc[1]
+	}
+	sb.WriteString(util.EscapeMarkdown(text[last:]))
+	return sb.String()
+}
+
+var markdownStripper = strings.NewReplacer("_", "", "*", "", "`", "", "[", "")
+
+func stripMarkdown(text string) string {
+	return markdownStripper.Replace(text)
+}
@@ -0,0 +1,43 @@
+package review
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+)
+
+func TestMatchWholeWords(t *testing.T) {
+	rules := []model.ReviewRule{{Keyword: "ads"}, {Keyword: "login"}, {Keyword: "lỗi"}, {Keyword: "not working"}}
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Too many ads!", []string{"ads"}},
+		{"ADS everywhere", []string{"ads"}},
+		{"Slow downloads and loads, heads up", nil},
+		{"Logins fail", nil},
+		{"Cannot login since update", []string{"login"}},
+		{"Ứng dụng bị lỗi", []string{"lỗi"}},
+		{"Sync is not working", []string{"not working"}},
+		{"ads,login", []string{"ads", "login"}},
+	}
+
+	for _, tt := range tests {
+		got := Match(rules, model.Review{Text: tt.text})
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Match(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFormatHighlightedWholeWords(t *testing.T) {
+	review := model.Review{Score: 2, Text: "ads in downloads", UserName: "user"}
+
+	got := FormatHighlighted(review, []string{"ads"})
+	want := "★★☆☆☆\n*ads* in downloads\n— user"
+	if got != want {
+		t.Errorf("FormatHighlighted = %q, want %q", got, want)
+	}
+}
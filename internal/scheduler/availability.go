@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/miti99/store-scraper-bot-go/internal/api"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// checkExtraStorefronts fetches an app in its additional storefronts, which
+// the scheduled report does not cover, only to track its availability there.
+func (s *Scheduler) checkExtraStorefronts(groupID int64, store string, appInfo model.AppInfo) {
+	for _, country := range appInfo.Countries {
+		var err error
+		if store == "Apple" {
+			_, err = s.appleScraper.GetApp(appInfo.AppID, country)
+		} else {
+			_, err = s.googleScraper.GetApp(appInfo.AppID, country)
+		}
+		s.trackAvailability(groupID, store, appInfo, country, err)
+	}
+}
+
+// trackAvailability updates the consecutive not-found counter of an app in
+// one storefront after a fetch. It alerts the group once the counter reaches
+// the threshold, and again when an app that was reported unavailable comes
+// back. Other errors are treated as transient and leave the counter untouched.
+func (s *Scheduler) trackAvailability(groupID int64, store string, appInfo model.AppInfo, country string, fetchErr error) {
+	previous := appInfo.NotFoundIn(country)
+	count := previous
+	switch {
+	case fetchErr == nil:
+		count = 0
+	case errors.Is(fetchErr, api.ErrNotFound):
+		count++
+	default:
+		return
+	}
+
+	if count == previous {
+		return
+	}
+
+	var err error
+	if store == "Apple" {
+		err = s.groupRepo.SetAppleAppNotFoundCount(groupID, appInfo.AppID, country, count)
+	} else {
+		err = s.groupRepo.SetGoogleAppNotFoundCount(groupID, appInfo.AppID, country, count)
+	}
+	if err != nil {
+		s.logger.Error("Failed to update not found count",
+			zap.Int64("groupId", groupID),
+			zap.String("appId", appInfo.AppID),
+			zap.String("country", country),
+			zap.Error(err))
+		return
+	}
+
+	threshold := s.cfg.NumNotFoundAlertThreshold
+	var message string
+	switch {
+	case count == threshold:
+		message = fmt.Sprintf("*App Unavailable in %s*\n%s app %s was not found in the %s store for %d consecutive checks. It may have been removed or restricted in this country.",
+			country, store, util.EscapeMarkdown(appInfo.AppID), country, count)
+	case count == 0 && previous >= threshold:
+		message = fmt.Sprintf("*App Available Again in %s*\n%s app %s is listed in the %s store again.",
+			country, store, util.EscapeMarkdown(appInfo.AppID), country)
+	default:
+		return
+	}
+
+	if err := s.bot.SendMessage(groupID, message); err != nil {
+		s.logger.Error("Failed to send availability alert",
+			zap.Int64("groupId", groupID),
+			zap.String("appId", appInfo.AppID),
+			zap.Error(err))
+	}
+}
@@ -0,0 +1,198 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/api"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// developerApp is the part of a store listing needed to diff developer apps.
+type developerApp struct {
+	AppID string
+	Title string
+}
+
+func (s *Scheduler) runDeveloperCheck() {
+	s.logger.Info("Running developer check job")
+
+	groups, err := s.adminRepo.GetAllGroups()
+	if err != nil {
+		s.logger.Error("Failed to get groups for developer check", zap.Error(err))
+		return
+	}
+
+	for _, groupID := range groups {
+		s.checkDevelopers(groupID)
+	}
+
+	s.logger.Info("Developer check job completed", zap.Int("groupsChecked", len(groups)))
+}
+
+// checkDevelopers enumerates the developers a group follows, adds newly
+// published apps to the group and reports apps no longer listed.
+func (s *Scheduler) checkDevelopers(groupID int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	group, err := s.groupRepo.Get(ctx, groupID)
+	if err != nil {
+		s.logger.Error("Failed to get group", zap.Int64("groupId", groupID), zap.Error(err))
+		return
+	}
+
+	if len(group.AppleDevelopers) == 0 && len(group.GoogleDevelopers) == 0 {
+		return
+	}
+
+	// The scrape can take minutes, so changes are written as targeted updates
+	// rather than saving the group loaded above over concurrent edits.
+	var lines []string
+
+	for i := range group.AppleDevelopers {
+		developer := &group.AppleDevelopers[i]
+		apps, err := s.appleScraper.GetDeveloperApps(developer.DeveloperID, developer.Country)
+		if err != nil {
+			s.logger.Error("Failed to fetch Apple developer apps",
+				zap.Int64("groupId", groupID),
+				zap.String("developerId", developer.DeveloperID),
+				zap.String("errorKind", api.Label(err)),
+				zap.Error(err))
+			continue
+		}
+
+		current := make([]developerApp, 0, len(apps))
+		for _, app := range apps {
+			current = append(current, developerApp{AppID: app.AppID, Title: app.Title})
+		}
+		developerLines, developerChanged := s.diffDeveloper("Apple", developer, current, func(appID, country string) bool {
+			return s.trackDeveloperApp(groupID, appID, country, s.groupRepo.TrackAppleApp)
+		})
+		if developerChanged {
+			if err := s.groupRepo.SetAppleDeveloperAppIDs(groupID, developer.DeveloperID, developer.Country, developer.AppIDs); err != nil {
+				s.logger.Error("Failed to update Apple developer apps",
+					zap.Int64("groupId", groupID),
+					zap.String("developerId", developer.DeveloperID),
+					zap.Error(err))
+			}
+		}
+		lines = append(lines, developerLines...)
+	}
+
+	for i := range group.GoogleDevelopers {
+		developer := &group.GoogleDevelopers[i]
+		apps, err := s.googleScraper.GetDeveloperApps(developer.DeveloperID, developer.Country)
+		if err != nil {
+			s.logger.Error("Failed to fetch Google developer apps",
+				zap.Int64("groupId", groupID),
+				zap.String("developerId", developer.DeveloperID),
+				zap.String("errorKind", api.Label(err)),
+				zap.Error(err))
+			continue
+		}
+
+		current := make([]developerApp, 0, len(apps))
+		for _, app := range apps {
+			current = append(current, developerApp{AppID: app.AppID, Title: app.Title})
+		}
+		developerLines, developerChanged := s.diffDeveloper("Google", developer, current, func(appID, country string) bool {
+			return s.trackDeveloperApp(groupID, appID, country, s.groupRepo.TrackGoogleApp)
+		})
+		if developerChanged {
+			if err := s.groupRepo.SetGoogleDeveloperAppIDs(groupID, developer.DeveloperID, developer.Country, developer.AppIDs); err != nil {
+				s.logger.Error("Failed to update Google developer apps",
+					zap.Int64("groupId", groupID),
+					zap.String("developerId", developer.DeveloperID),
+					zap.Error(err))
+			}
+		}
+		lines = append(lines, developerLines...)
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("*Developer Apps Update*\nGroup: %d\n\n%s", groupID, strings.Join(lines, "\n"))
+	if err := s.bot.SendMessage(groupID, message); err != nil {
+		s.logger.Error("Failed to send developer update",
+			zap.Int64("groupId", groupID),
+			zap.Error(err))
+	}
+}
+
+// diffDeveloper updates the developer's known apps and returns report lines
+// and whether anything changed. New apps are added to the group through addApp.
+func (s *Scheduler) diffDeveloper(
+	store string,
+	developer *model.DeveloperInfo,
+	current []developerApp,
+	addApp func(appID, country string) bool,
+) ([]string, bool) {
+	// An empty listing for a developer with known apps is more likely an
+	// upstream hiccup than every app being removed at once
+	if len(current) == 0 && len(developer.AppIDs) > 0 {
+		s.logger.Warn("Developer returned no apps, skipping",
+			zap.String("store", store),
+			zap.String("developerId", developer.DeveloperID))
+		return nil, false
+	}
+
+	known := make(map[string]bool, len(developer.AppIDs))
+	for _, appID := range developer.AppIDs {
+		known[appID] = true
+	}
+
+	var lines []string
+	changed := len(current) != len(developer.AppIDs)
+	appIDs := make([]string, 0, len(current))
+	for _, app := range current {
+		appIDs = append(appIDs, app.AppID)
+		if known[app.AppID] {
+			delete(known, app.AppID)
+			continue
+		}
+
+		changed = true
+		if addApp(app.AppID, developer.Country) {
+			lines = append(lines, fmt.Sprintf("New %s app from %s: %s (%s), now tracked",
+				store, util.EscapeMarkdown(developerName(developer)), util.Bold(app.Title), util.EscapeMarkdown(app.AppID)))
+		}
+	}
+
+	for _, appID := range developer.AppIDs {
+		if known[appID] {
+			lines = append(lines, fmt.Sprintf("%s app %s is no longer listed under %s",
+				store, util.EscapeMarkdown(appID), util.EscapeMarkdown(developerName(developer))))
+		}
+	}
+
+	developer.AppIDs = appIDs
+	return lines, changed
+}
+
+// trackDeveloperApp adds an app found under a developer and reports whether
+// it was new to the group.
+func (s *Scheduler) trackDeveloperApp(groupID int64, appID, country string, track func(int64, string, string) (bool, error)) bool {
+	added, err := track(groupID, appID, country)
+	if err != nil {
+		s.logger.Error("Failed to track developer app",
+			zap.Int64("groupId", groupID),
+			zap.String("appId", appID),
+			zap.Error(err))
+		return false
+	}
+	return added
+}
+
+func developerName(developer *model.DeveloperInfo) string {
+	if developer.Name != "" {
+		return developer.Name
+	}
+	return developer.DeveloperID
+}
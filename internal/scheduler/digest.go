@@ -0,0 +1,260 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/chart"
+	"github.com/miti99/store-scraper-bot-go/internal/metrics"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/review"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// maxDigestCharts caps the history charts attached to a digest.
+const maxDigestCharts = 5
+
+// digestApp is the activity of one app storefront over a digest period.
+type digestApp struct {
+	Source    reviewSource
+	Title     string
+	Snapshots []model.AppSnapshot // Period history, oldest first
+}
+
+func (s *Scheduler) runWeeklyDigest() {
+	s.runDigest(model.DigestWeekly, "Weekly", 0, 7)
+}
+
+func (s *Scheduler) runMonthlyDigest() {
+	s.runDigest(model.DigestMonthly, "Monthly", 1, 0)
+}
+
+// runDigest sends the groups subscribed to the period a summary of the last
+// months and days.
+func (s *Scheduler) runDigest(period, name string, months, days int) {
+	s.logger.Info("Running digest job", zap.String("digest", name))
+
+	groups, err := s.adminRepo.GetAllGroups()
+	if err != nil {
+		s.logger.Error("Failed to get groups for digest", zap.String("digest", name), zap.Error(err))
+		return
+	}
+
+	now := time.Now().In(s.cfg.VietnamLocation)
+	since := now.AddDate(0, -months, -days)
+	for _, groupID := range groups {
+		s.sendDigest(groupID, period, name, since, now)
+	}
+
+	s.logger.Info("Digest job completed", zap.String("digest", name), zap.Int("groupsChecked", len(groups)))
+}
+
+func (s *Scheduler) sendDigest(groupID int64, period, name string, since, now time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	group, err := s.groupRepo.Get(ctx, groupID)
+	cancel()
+	if err != nil {
+		s.logger.Error("Failed to get group", zap.Int64("groupId", groupID), zap.Error(err))
+		return
+	}
+
+	if !group.WantsDigest(period) {
+		return
+	}
+
+	apps := s.digestApps(group, since)
+	if len(apps) == 0 {
+		return
+	}
+
+	header := fmt.Sprintf("*%s Digest*\nGroup: %d\nPeriod: %s to %s",
+		name, groupID, since.Format("2006-01-02"), now.Format("2006-01-02"))
+	sections := []string{header, buildDigestOverview(apps)}
+	if section := buildVersionsSection(apps, since); section != "" {
+		sections = append(sections, section)
+	}
+	if section := s.buildStaleSection(apps, now); section != "" {
+		sections = append(sections, section)
+	}
+	if section := s.buildTopReviewsSection(apps, since); section != "" {
+		sections = append(sections, section)
+	}
+	if summaries := s.reviewSummarySections(group, since); len(summaries) > 0 {
+		sections = append(sections, "*Review Sentiment*\nCompared to the period before")
+		sections = append(sections, summaries...)
+	}
+
+	// The bot splits the digest at its blank lines when it is too long
+	if err := s.bot.SendMessageSilent(groupID, strings.Join(sections, "\n\n")); err != nil {
+		s.logger.Error("Failed to send digest", zap.Int64("groupId", groupID), zap.String("digest", name), zap.Error(err))
+		return
+	}
+
+	s.sendDigestCharts(groupID, apps)
+}
+
+// digestApps loads the period history of the group's unmuted apps.
+func (s *Scheduler) digestApps(group *model.Group, since time.Time) []digestApp {
+	var apps []digestApp
+	for _, source := range reviewSources(group) {
+		snapshots, err := s.snapshotRepo.GetHistory(source.Store, source.AppID, source.Country, since)
+		if err != nil {
+			s.logger.Error("Failed to get snapshots for digest",
+				zap.String("appId", source.AppID),
+				zap.Error(err))
+			continue
+		}
+
+		app := digestApp{Source: source, Title: source.AppID, Snapshots: snapshots}
+		if len(snapshots) > 0 {
+			app.Title = snapshots[len(snapshots)-1].Title
+		}
+		apps = append(apps, app)
+	}
+	return apps
+}
+
+func buildDigestOverview(apps []digestApp) string {
+	var rows [][]string
+	for _, app := range apps {
+		if len(app.Snapshots) == 0 {
+			rows = append(rows, []string{util.TruncateString(app.Title, 24), storeTitle(app.Source.Store), "-", "-", "-", "-"})
+			continue
+		}
+
+		latest := app.Snapshots[len(app.Snapshots)-1]
+		delta, gained := "-", "-"
+		if d, ok := metrics.ScoreDelta(app.Snapshots); ok {
+			delta = fmt.Sprintf("%+.2f", d)
+		}
+		if n, ok := metrics.RatingsGained(app.Snapshots); ok {
+			gained = fmt.Sprintf("%+d", n)
+		}
+		rows = append(rows, []string{
+			util.TruncateString(app.Title, 24),
+			storeTitle(app.Source.Store),
+			fmt.Sprintf("%.2f", latest.Score),
+			delta,
+			util.FormatNumber(latest.Ratings),
+			gained,
+		})
+	}
+
+	headers := []string{"App", "Store", "Score", "Delta", "Ratings", "Gained"}
+	return "*Overview*\n" + strings.TrimRight(util.BuildTable(headers, rows), "\n")
+}
+
+func buildVersionsSection(apps []digestApp, since time.Time) string {
+	var lines []string
+	for _, app := range apps {
+		if versions := metrics.NewVersions(app.Snapshots, since); len(versions) > 0 {
+			lines = append(lines, fmt.Sprintf("%s (%s): %s",
+				util.EscapeMarkdown(app.Title), storeTitle(app.Source.Store),
+				util.EscapeMarkdown(strings.Join(versions, ", "))))
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "*New Versions*\n" + strings.Join(lines, "\n")
+}
+
+func (s *Scheduler) buildStaleSection(apps []digestApp, now time.Time) string {
+	var lines []string
+	for _, app := range apps {
+		if len(app.Snapshots) == 0 {
+			continue
+		}
+		updated := app.Snapshots[len(app.Snapshots)-1].Updated
+		if updated.IsZero() {
+			continue
+		}
+		if days := int(now.Sub(updated).Hours() / 24); days > s.cfg.NumDaysWarningNotUpdated {
+			lines = append(lines, fmt.Sprintf("%s (%s): %d days since %s",
+				util.EscapeMarkdown(app.Title), storeTitle(app.Source.Store), days, updated.Format("2006-01-02")))
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("*Not Updated in >%d Days*\n%s", s.cfg.NumDaysWarningNotUpdated, strings.Join(lines, "\n"))
+}
+
+// buildTopReviewsSection shows the best and the worst review of each app
+// over the period, preferring longer texts among equal scores.
+func (s *Scheduler) buildTopReviewsSection(apps []digestApp, since time.Time) string {
+	var parts []string
+	for _, app := range apps {
+		reviews, err := s.reviewCollector.GetRecent(app.Source.Store, app.Source.AppID, app.Source.Country, s.cfg.ReviewSummarySize)
+		if err != nil {
+			s.logger.Error("Failed to get reviews for digest",
+				zap.String("appId", app.Source.AppID),
+				zap.Error(err))
+			continue
+		}
+
+		var period []model.Review
+		for _, r := range reviews {
+			if !r.Date.Before(since) {
+				period = append(period, r)
+			}
+		}
+		if len(period) == 0 {
+			continue
+		}
+
+		sort.SliceStable(period, func(i, j int) bool {
+			if period[i].Score != period[j].Score {
+				return period[i].Score > period[j].Score
+			}
+			return len(period[i].Text) > len(period[j].Text)
+		})
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("*%s* (%s, %s)\n%s", app.Source.AppID, storeTitle(app.Source.Store), app.Source.Country, review.Format(period[0])))
+		// Longest text among the lowest scores
+		worst := len(period) - 1
+		for worst > 0 && period[worst-1].Score == period[worst].Score {
+			worst--
+		}
+		if period[worst].Score < period[0].Score {
+			sb.WriteString("\n\n" + review.Format(period[worst]))
+		}
+		parts = append(parts, sb.String())
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "*Top Reviews*\n\n" + strings.Join(parts, "\n\n")
+}
+
+// sendDigestCharts attaches the score and ratings history of the first apps
+// with enough history to draw.
+func (s *Scheduler) sendDigestCharts(groupID int64, apps []digestApp) {
+	sent := 0
+	for _, app := range apps {
+		if sent == maxDigestCharts {
+			return
+		}
+		if len(app.Snapshots) < 2 {
+			continue
+		}
+
+		image, err := chart.History(app.Snapshots)
+		if err != nil {
+			s.logger.Error("Failed to render digest chart", zap.String("appId", app.Source.AppID), zap.Error(err))
+			continue
+		}
+
+		caption := fmt.Sprintf("%s (%s, %s)", util.EscapeMarkdown(app.Title), storeTitle(app.Source.Store), app.Source.Country)
+		if err := s.bot.SendPhoto(groupID, image, caption); err != nil {
+			s.logger.Error("Failed to send digest chart", zap.Int64("groupId", groupID), zap.Error(err))
+			return
+		}
+		sent++
+	}
+}
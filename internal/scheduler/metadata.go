@@ -0,0 +1,159 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// maxDescriptionDiffLines caps the description diff shown per change.
+const maxDescriptionDiffLines = 20
+
+// runChangeNotifications posts the listing changes detected since the last
+// run to every group tracking the app storefront, then marks them notified.
+func (s *Scheduler) runChangeNotifications() {
+	changes, err := s.changeRepo.GetPending()
+	if err != nil {
+		s.logger.Error("Failed to get pending metadata changes", zap.Error(err))
+		return
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	s.logger.Info("Running change notification job", zap.Int("changes", len(changes)))
+
+	groupIDs, err := s.adminRepo.GetAllGroups()
+	if err != nil {
+		s.logger.Error("Failed to get groups for change notifications", zap.Error(err))
+		return
+	}
+
+	for _, groupID := range groupIDs {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		group, err := s.groupRepo.Get(ctx, groupID)
+		cancel()
+		if err != nil {
+			s.logger.Error("Failed to get group", zap.Int64("groupId", groupID), zap.Error(err))
+			continue
+		}
+
+		var priceSections, sections []string
+		for _, change := range changes {
+			if !tracksStorefront(group, change.Store, change.AppID, change.Country) {
+				continue
+			}
+			prices, others := splitPriceChanges(change.Changes)
+			if len(prices) > 0 {
+				priceSections = append(priceSections, buildChangeSection(change, prices))
+			}
+			if len(others) > 0 {
+				sections = append(sections, buildChangeSection(change, others))
+			}
+		}
+
+		if len(priceSections) > 0 {
+			message := fmt.Sprintf("*Price Changes*\n\n%s", strings.Join(priceSections, "\n\n"))
+			if err := s.bot.SendMessage(group.Key, message); err != nil {
+				s.logger.Error("Failed to send price changes", zap.Int64("groupId", group.Key), zap.Error(err))
+			}
+		}
+
+		if len(sections) > 0 {
+			message := fmt.Sprintf("*Metadata Changes*\n\n%s", strings.Join(sections, "\n\n"))
+			if err := s.bot.SendMessage(group.Key, message); err != nil {
+				s.logger.Error("Failed to send metadata changes", zap.Int64("groupId", group.Key), zap.Error(err))
+			}
+		}
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(changes))
+	for _, change := range changes {
+		ids = append(ids, change.ID)
+	}
+	if err := s.changeRepo.MarkNotified(ids); err != nil {
+		s.logger.Error("Failed to mark metadata changes notified", zap.Error(err))
+	}
+}
+
+// tracksStorefront reports whether the group has the app unmuted in the
+// storefront.
+func tracksStorefront(group *model.Group, store, appID, country string) bool {
+	apps := group.GoogleApps
+	if store == model.StoreApple {
+		apps = group.AppleApps
+	}
+	for _, app := range apps {
+		if app.AppID == appID && !app.Muted && app.HasStorefront(country) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitPriceChanges separates price changes, posted as their own alert, from
+// the other listing changes.
+func splitPriceChanges(changes []model.FieldChange) (prices, others []model.FieldChange) {
+	for _, c := range changes {
+		if c.Field == model.FieldPrice {
+			prices = append(prices, c)
+		} else {
+			others = append(others, c)
+		}
+	}
+	return prices, others
+}
+
+func buildChangeSection(change model.MetadataChange, fields []model.FieldChange) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("*%s* (%s, %s)\n%s",
+		change.AppID, storeTitle(change.Store), change.Country,
+		util.EscapeMarkdown(change.Title)))
+
+	for _, c := range fields {
+		sb.WriteString("\n")
+		switch c.Field {
+		case model.FieldTitle:
+			sb.WriteString(fmt.Sprintf("Title: %s → %s", util.EscapeMarkdown(c.Old), util.EscapeMarkdown(c.New)))
+		case model.FieldIcon:
+			sb.WriteString("Icon changed")
+		case model.FieldScreenshots:
+			sb.WriteString(fmt.Sprintf("Screenshots changed: %s → %s", c.Old, c.New))
+		case model.FieldPrice:
+			sb.WriteString(fmt.Sprintf("%s: %s → %s", priceChangeTitle(c.Kind), c.Old, c.New))
+		case model.FieldContentRating:
+			sb.WriteString(fmt.Sprintf("Content rating: %s → %s", util.EscapeMarkdown(c.Old), util.EscapeMarkdown(c.New)))
+		case model.FieldDescription:
+			sb.WriteString("Description changed:\n```\n")
+			for _, line := range util.DiffLines(c.Old, c.New, maxDescriptionDiffLines) {
+				// A backtick would close the code block early
+				line = strings.ReplaceAll(line, "`", "'")
+				sb.WriteString(util.TruncateString(line, 200))
+				sb.WriteString("\n")
+			}
+			sb.WriteString("```")
+		}
+	}
+	return sb.String()
+}
+
+func priceChangeTitle(kind string) string {
+	switch kind {
+	case model.PriceDrop:
+		return "Price drop"
+	case model.PriceIncrease:
+		return "Price increase"
+	case model.PriceNowFree:
+		return "Now free"
+	case model.PriceNowPaid:
+		return "Now paid"
+	default:
+		return "Price changed"
+	}
+}
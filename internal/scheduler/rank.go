@@ -0,0 +1,176 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/api"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// chartMove is the day over day position of a ranking's apps.
+type chartMove struct {
+	Current  *model.ChartRanking
+	Previous *model.ChartRanking // Nil on the first capture
+}
+
+// runRankCheck captures every tracked chart once, then alerts each group
+// about its apps entering or leaving the top positions.
+func (s *Scheduler) runRankCheck() {
+	s.logger.Info("Running rank check job")
+
+	groupIDs, err := s.adminRepo.GetAllGroups()
+	if err != nil {
+		s.logger.Error("Failed to get groups for rank check", zap.Error(err))
+		return
+	}
+
+	groups := make([]*model.Group, 0, len(groupIDs))
+	for _, groupID := range groupIDs {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		group, err := s.groupRepo.Get(ctx, groupID)
+		cancel()
+		if err != nil {
+			s.logger.Error("Failed to get group", zap.Int64("groupId", groupID), zap.Error(err))
+			continue
+		}
+		groups = append(groups, group)
+	}
+
+	moves := make(map[string]chartMove)
+	for _, group := range groups {
+		for _, chart := range group.Charts {
+			if _, ok := moves[chart.Key()]; ok {
+				continue
+			}
+			move, err := s.captureChart(chart)
+			if err != nil {
+				s.logger.Error("Failed to capture chart",
+					zap.String("chart", chart.Key()),
+					zap.String("errorKind", api.Label(err)),
+					zap.Error(err))
+				continue
+			}
+			moves[chart.Key()] = move
+		}
+	}
+
+	for _, group := range groups {
+		s.postRankAlerts(group, moves)
+	}
+
+	s.logger.Info("Rank check job completed",
+		zap.Int("groupsChecked", len(groups)),
+		zap.Int("charts", len(moves)))
+}
+
+// captureChart stores today's ranking and returns it with the ranking of the
+// last earlier day. Incomplete responses are neither stored nor compared.
+func (s *Scheduler) captureChart(chart model.ChartInfo) (chartMove, error) {
+	var entries []model.ChartEntry
+	var err error
+	if chart.Store == model.StoreApple {
+		entries, err = s.appleScraper.GetTopChart(chart, s.cfg.RankChartSize)
+	} else {
+		entries, err = s.googleScraper.GetTopChart(chart, s.cfg.RankChartSize)
+	}
+	if err != nil {
+		return chartMove{}, err
+	}
+
+	// A failed scrape can return no or a few entries. Saving it would read as
+	// every app leaving the top positions, and re-entering on the next run.
+	if len(entries) < min(s.cfg.RankAlertTop, s.cfg.RankChartSize) {
+		return chartMove{}, fmt.Errorf("incomplete chart response: %d entries", len(entries))
+	}
+
+	move := chartMove{Current: model.NewChartRanking(chart, entries)}
+
+	recent, err := s.rankingRepo.GetRecent(chart, 2)
+	if err != nil {
+		return chartMove{}, err
+	}
+	for i := range recent {
+		if recent[i].Date != move.Current.Date {
+			move.Previous = &recent[i]
+			break
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.rankingRepo.Save(ctx, move.Current); err != nil {
+		return chartMove{}, err
+	}
+	return move, nil
+}
+
+func (s *Scheduler) postRankAlerts(group *model.Group, moves map[string]chartMove) {
+	top := s.cfg.RankAlertTop
+
+	var lines []string
+	for _, chart := range group.Charts {
+		move, ok := moves[chart.Key()]
+		if !ok || move.Previous == nil {
+			continue // Nothing to compare on the first capture
+		}
+
+		apps := group.GoogleApps
+		if chart.Store == model.StoreApple {
+			apps = group.AppleApps
+		}
+		for _, app := range apps {
+			if app.Muted {
+				continue
+			}
+			now := move.Current.Position(app.AppID)
+			before := move.Previous.Position(app.AppID)
+			inTop, wasInTop := now > 0 && now <= top, before > 0 && before <= top
+			if inTop == wasInTop {
+				continue
+			}
+
+			action := "Entered"
+			if wasInTop {
+				action = "Left"
+			}
+			lines = append(lines, fmt.Sprintf("%s top %d of %s (%s, %s): %s, %s → %s",
+				action, top, util.EscapeMarkdown(chart.Name()), storeTitle(chart.Store), chart.Country,
+				util.EscapeMarkdown(rankedTitle(move, app.AppID)),
+				positionText(before), positionText(now)))
+		}
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("*Chart Alert*\n\n%s", strings.Join(lines, "\n"))
+	if err := s.bot.SendMessage(group.Key, message); err != nil {
+		s.logger.Error("Failed to send chart alert", zap.Int64("groupId", group.Key), zap.Error(err))
+	}
+}
+
+// rankedTitle returns the app title from either ranking, the app ID if the
+// app is in neither.
+func rankedTitle(move chartMove, appID string) string {
+	for _, ranking := range []*model.ChartRanking{move.Current, move.Previous} {
+		for _, e := range ranking.Entries {
+			if e.AppID == appID && e.Title != "" {
+				return e.Title
+			}
+		}
+	}
+	return appID
+}
+
+func positionText(position int) string {
+	if position == 0 {
+		return "unranked"
+	}
+	return fmt.Sprintf("#%d", position)
+}
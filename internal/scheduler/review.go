@@ -0,0 +1,165 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/api"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/review"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// maxReviewsPerApp caps the reviews posted per app and run.
+const maxReviewsPerApp = 10
+
+// reviewSource is an app storefront whose reviews are collected.
+type reviewSource struct {
+	Store   string
+	AppID   string
+	Country string
+}
+
+func (r reviewSource) key() string {
+	return r.Store + ":" + model.AppKey(r.AppID, r.Country)
+}
+
+// runReviewCheck collects new reviews once per storefront, then posts the
+// low-star ones to every group tracking the app.
+func (s *Scheduler) runReviewCheck() {
+	s.logger.Info("Running review check job")
+
+	groupIDs, err := s.adminRepo.GetAllGroups()
+	if err != nil {
+		s.logger.Error("Failed to get groups for review check", zap.Error(err))
+		return
+	}
+
+	groups := make([]*model.Group, 0, len(groupIDs))
+	for _, groupID := range groupIDs {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		group, err := s.groupRepo.Get(ctx, groupID)
+		cancel()
+		if err != nil {
+			s.logger.Error("Failed to get group", zap.Int64("groupId", groupID), zap.Error(err))
+			continue
+		}
+		groups = append(groups, group)
+	}
+
+	newReviews := make(map[string][]model.Review)
+	collected := make(map[string]bool)
+	for _, group := range groups {
+		for _, source := range reviewSources(group) {
+			if collected[source.key()] {
+				continue
+			}
+			collected[source.key()] = true
+
+			reviews, err := s.reviewCollector.Collect(source.Store, source.AppID, source.Country)
+			if err != nil {
+				s.logger.Error("Failed to collect reviews",
+					zap.String("store", source.Store),
+					zap.String("appId", source.AppID),
+					zap.String("errorKind", api.Label(err)),
+					zap.Error(err))
+				continue
+			}
+			newReviews[source.key()] = reviews
+		}
+	}
+
+	for _, group := range groups {
+		s.postReviews(group, newReviews)
+	}
+
+	s.logger.Info("Review check job completed",
+		zap.Int("groupsChecked", len(groups)),
+		zap.Int("storefronts", len(collected)))
+}
+
+// reviewSources lists the primary storefronts of the group's unmuted apps.
+func reviewSources(group *model.Group) []reviewSource {
+	var sources []reviewSource
+	for _, app := range group.AppleApps {
+		if !app.Muted {
+			sources = append(sources, reviewSource{model.StoreApple, app.AppID, app.Country})
+		}
+	}
+	for _, app := range group.GoogleApps {
+		if !app.Muted {
+			sources = append(sources, reviewSource{model.StoreGoogle, app.AppID, app.Country})
+		}
+	}
+	return sources
+}
+
+// postReviews alerts the group about new reviews matching its keyword rules,
+// then posts the remaining new low-star reviews.
+func (s *Scheduler) postReviews(group *model.Group, newReviews map[string][]model.Review) {
+	var alerts, sections []string
+	for _, source := range reviewSources(group) {
+		var lowStar []model.Review
+		for _, r := range newReviews[source.key()] {
+			if keywords := review.Match(group.ReviewRules, r); len(keywords) > 0 {
+				alerts = append(alerts, buildKeywordAlert(source, r, keywords))
+				continue
+			}
+			if r.Score <= s.cfg.ReviewAlertMaxScore {
+				lowStar = append(lowStar, r)
+			}
+		}
+		if len(lowStar) > 0 {
+			sections = append(sections, buildReviewSection(source, lowStar))
+		}
+	}
+
+	if len(alerts) > 0 {
+		message := fmt.Sprintf("*Review Keyword Alert*\n\n%s", strings.Join(alerts, "\n\n"))
+		if err := s.bot.SendMessage(group.Key, message); err != nil {
+			s.logger.Error("Failed to send keyword alert", zap.Int64("groupId", group.Key), zap.Error(err))
+		}
+	}
+
+	if len(sections) > 0 {
+		message := fmt.Sprintf("*New Low-Star Reviews*\n\n%s", strings.Join(sections, "\n\n"))
+		if err := s.bot.SendMessage(group.Key, message); err != nil {
+			s.logger.Error("Failed to send review alert", zap.Int64("groupId", group.Key), zap.Error(err))
+		}
+	}
+}
+
+func buildKeywordAlert(source reviewSource, r model.Review, keywords []string) string {
+	return fmt.Sprintf("*%s* (%s, %s)\nMatched: %s\n%s",
+		source.AppID, storeTitle(source.Store), source.Country,
+		util.EscapeMarkdown(strings.Join(keywords, ", ")),
+		review.FormatHighlighted(r, keywords))
+}
+
+func buildReviewSection(source reviewSource, reviews []model.Review) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("*%s* (%s, %s)", source.AppID, storeTitle(source.Store), source.Country))
+
+	shown := reviews
+	if len(shown) > maxReviewsPerApp {
+		shown = shown[len(shown)-maxReviewsPerApp:]
+	}
+	for _, r := range shown {
+		sb.WriteString("\n\n")
+		sb.WriteString(review.Format(r))
+	}
+	if len(reviews) > len(shown) {
+		sb.WriteString(fmt.Sprintf("\n\n...and %d more", len(reviews)-len(shown)))
+	}
+	return sb.String()
+}
+
+func storeTitle(store string) string {
+	if store == model.StoreApple {
+		return "Apple"
+	}
+	return "Google"
+}
@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/analysis"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// maxSummaryTopics caps the negative topics listed per app.
+const maxSummaryTopics = 5
+
+// reviewSummarySections summarizes the stored reviews of each unmuted app
+// that received reviews since the given time, compared to the equally long
+// period before.
+func (s *Scheduler) reviewSummarySections(group *model.Group, since time.Time) []string {
+	now := time.Now()
+	period := now.Sub(since)
+	var sections []string
+
+	for _, source := range reviewSources(group) {
+		reviews, err := s.reviewCollector.GetRecent(source.Store, source.AppID, source.Country, s.cfg.ReviewSummarySize)
+		if err != nil {
+			s.logger.Error("Failed to get reviews for summary",
+				zap.String("appId", source.AppID),
+				zap.Error(err))
+			continue
+		}
+
+		current, previous := splitByPeriod(reviews, now, period)
+		if len(current) == 0 {
+			continue
+		}
+
+		summary := analysis.Summarize(current, previous, maxSummaryTopics)
+		sections = append(sections, buildSummarySection(source, summary))
+	}
+
+	return sections
+}
+
+// splitByPeriod separates reviews of the current period from those of the
+// period before.
+func splitByPeriod(reviews []model.Review, now time.Time, period time.Duration) ([]model.Review, []model.Review) {
+	var current, previous []model.Review
+	for _, r := range reviews {
+		age := now.Sub(r.Date)
+		switch {
+		case age < period:
+			current = append(current, r)
+		case age < 2*period:
+			previous = append(previous, r)
+		}
+	}
+	return current, previous
+}
+
+func buildSummarySection(source reviewSource, summary analysis.Summary) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("*%s* (%s, %s)\n", source.AppID, storeTitle(source.Store), source.Country))
+	sb.WriteString(fmt.Sprintf("Reviews: %d (previous %d)\n", summary.Current.Reviews, summary.Previous.Reviews))
+	sb.WriteString(fmt.Sprintf("Sentiment: %+.2f", summary.Current.Sentiment))
+	if summary.Previous.Reviews > 0 {
+		sb.WriteString(fmt.Sprintf(" (previous %+.2f)", summary.Previous.Sentiment))
+	}
+	sb.WriteString(fmt.Sprintf("\nPositive/neutral/negative: %d/%d/%d",
+		summary.Current.Positive, summary.Current.Neutral, summary.Current.Negative))
+
+	if len(summary.Topics) > 0 {
+		topics := make([]string, 0, len(summary.Topics))
+		for _, topic := range summary.Topics {
+			topics = append(topics, fmt.Sprintf("'%s' %d (%s)", util.EscapeMarkdown(topic.Term), topic.Current, describeTopicChange(topic)))
+		}
+		sb.WriteString("\nNegative mentions: " + strings.Join(topics, ", "))
+	}
+
+	return sb.String()
+}
+
+// describeTopicChange compares the mentions with the previous period, e.g.
+// "up 3.0x".
+func describeTopicChange(topic analysis.Topic) string {
+	if topic.Previous == 0 {
+		return "new"
+	}
+
+	ratio := float64(topic.Current) / float64(topic.Previous)
+	switch {
+	case ratio >= 1.5:
+		return fmt.Sprintf("up %.1fx", ratio)
+	case ratio <= 0.67:
+		return fmt.Sprintf("down from %d", topic.Previous)
+	default:
+		return "steady"
+	}
+}
@@ -3,28 +3,37 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/miti99/store-scraper-bot-go/internal/api"
 	"github.com/miti99/store-scraper-bot-go/internal/api/apple"
 	"github.com/miti99/store-scraper-bot-go/internal/api/google"
 	"github.com/miti99/store-scraper-bot-go/internal/bot"
 	"github.com/miti99/store-scraper-bot-go/internal/config"
+	"github.com/miti99/store-scraper-bot-go/internal/metrics"
 	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"github.com/miti99/store-scraper-bot-go/internal/report"
 	"github.com/miti99/store-scraper-bot-go/internal/repository"
+	"github.com/miti99/store-scraper-bot-go/internal/review"
 	"github.com/miti99/store-scraper-bot-go/internal/util"
 	"github.com/robfig/cron/v3"
 	"go.uber.org/zap"
 )
 
 type Scheduler struct {
-	cron          *cron.Cron
-	cfg           *config.Config
-	bot           *bot.Bot
-	adminRepo     *repository.AdminRepository
-	groupRepo     *repository.GroupRepository
-	appleScraper  *apple.AppleScraper
-	googleScraper *google.GoogleScraper
-	logger        *zap.Logger
+	cron            *cron.Cron
+	cfg             *config.Config
+	bot             *bot.Bot
+	adminRepo       *repository.AdminRepository
+	groupRepo       *repository.GroupRepository
+	snapshotRepo    *repository.SnapshotRepository
+	changeRepo      *repository.ChangeRepository
+	rankingRepo     *repository.RankingRepository
+	appleScraper    *apple.AppleScraper
+	googleScraper   *google.GoogleScraper
+	reviewCollector *review.Collector
+	logger          *zap.Logger
 }
 
 func NewScheduler(
@@ -32,21 +41,29 @@ func NewScheduler(
 	bot *bot.Bot,
 	adminRepo *repository.AdminRepository,
 	groupRepo *repository.GroupRepository,
+	snapshotRepo *repository.SnapshotRepository,
+	changeRepo *repository.ChangeRepository,
+	rankingRepo *repository.RankingRepository,
 	appleScraper *apple.AppleScraper,
 	googleScraper *google.GoogleScraper,
+	reviewCollector *review.Collector,
 ) *Scheduler {
 	// Create cron with Vietnam timezone
 	c := cron.New(cron.WithLocation(cfg.VietnamLocation))
 
 	return &Scheduler{
-		cron:          c,
-		cfg:           cfg,
-		bot:           bot,
-		adminRepo:     adminRepo,
-		groupRepo:     groupRepo,
-		appleScraper:  appleScraper,
-		googleScraper: googleScraper,
-		logger:        cfg.Logger,
+		cron:            c,
+		cfg:             cfg,
+		bot:             bot,
+		adminRepo:       adminRepo,
+		groupRepo:       groupRepo,
+		snapshotRepo:    snapshotRepo,
+		changeRepo:      changeRepo,
+		rankingRepo:     rankingRepo,
+		appleScraper:    appleScraper,
+		googleScraper:   googleScraper,
+		reviewCollector: reviewCollector,
+		logger:          cfg.Logger,
 	}
 }
 
@@ -57,8 +74,55 @@ func (s *Scheduler) Start() error {
 		return fmt.Errorf("failed to schedule daily check: %w", err)
 	}
 
+	_, err = s.cron.AddFunc(s.cfg.ScheduleCheckDeveloperTime, s.runDeveloperCheck)
+	if err != nil {
+		return fmt.Errorf("failed to schedule developer check: %w", err)
+	}
+
+	_, err = s.cron.AddFunc(s.cfg.ScheduleSnapshotTime, s.runSnapshotCapture)
+	if err != nil {
+		return fmt.Errorf("failed to schedule snapshot capture: %w", err)
+	}
+
+	_, err = s.cron.AddFunc(s.cfg.ScheduleCheckReviewTime, s.runReviewCheck)
+	if err != nil {
+		return fmt.Errorf("failed to schedule review check: %w", err)
+	}
+
+	// Digests are optional, an empty schedule disables them
+	if s.cfg.ScheduleWeeklyDigestTime != "" {
+		_, err = s.cron.AddFunc(s.cfg.ScheduleWeeklyDigestTime, s.runWeeklyDigest)
+		if err != nil {
+			return fmt.Errorf("failed to schedule weekly digest: %w", err)
+		}
+	}
+
+	if s.cfg.ScheduleMonthlyDigestTime != "" {
+		_, err = s.cron.AddFunc(s.cfg.ScheduleMonthlyDigestTime, s.runMonthlyDigest)
+		if err != nil {
+			return fmt.Errorf("failed to schedule monthly digest: %w", err)
+		}
+	}
+
+	_, err = s.cron.AddFunc(s.cfg.ScheduleNotifyChangesTime, s.runChangeNotifications)
+	if err != nil {
+		return fmt.Errorf("failed to schedule change notifications: %w", err)
+	}
+
+	_, err = s.cron.AddFunc(s.cfg.ScheduleCheckRankTime, s.runRankCheck)
+	if err != nil {
+		return fmt.Errorf("failed to schedule rank check: %w", err)
+	}
+
 	s.logger.Info("Scheduler started",
 		zap.String("schedule", s.cfg.ScheduleCheckAppTime),
+		zap.String("developerSchedule", s.cfg.ScheduleCheckDeveloperTime),
+		zap.String("snapshotSchedule", s.cfg.ScheduleSnapshotTime),
+		zap.String("reviewSchedule", s.cfg.ScheduleCheckReviewTime),
+		zap.String("weeklyDigestSchedule", s.cfg.ScheduleWeeklyDigestTime),
+		zap.String("monthlyDigestSchedule", s.cfg.ScheduleMonthlyDigestTime),
+		zap.String("changeSchedule", s.cfg.ScheduleNotifyChangesTime),
+		zap.String("rankSchedule", s.cfg.ScheduleCheckRankTime),
 		zap.String("timezone", s.cfg.VietnamLocation.String()))
 
 	s.cron.Start()
@@ -107,16 +171,25 @@ func (s *Scheduler) checkGroup(groupID int64, isWeekend bool) {
 	}
 
 	nonUpdatedApps := make([]model.NonUpdatedApp, 0)
+	failedApps := make([]model.FailedApp, 0)
 	now := time.Now().In(s.cfg.VietnamLocation)
 
 	// Check Apple apps
 	for _, appInfo := range group.AppleApps {
+		if appInfo.Muted {
+			continue
+		}
+
 		app, err := s.appleScraper.GetApp(appInfo.AppID, appInfo.Country)
+		s.trackAvailability(groupID, "Apple", appInfo, appInfo.Country, err)
+		s.checkExtraStorefronts(groupID, "Apple", appInfo)
 		if err != nil {
 			s.logger.Error("Failed to fetch Apple app",
 				zap.Int64("groupId", groupID),
 				zap.String("appId", appInfo.AppID),
+				zap.String("errorKind", api.Label(err)),
 				zap.Error(err))
+			failedApps = append(failedApps, model.NewFailedApp(appInfo, api.Label(err), true))
 			continue
 		}
 
@@ -127,6 +200,7 @@ func (s *Scheduler) checkGroup(groupID int64, isWeekend bool) {
 				zap.String("appId", appInfo.AppID),
 				zap.String("updated", app.Updated),
 				zap.Error(err))
+			failedApps = append(failedApps, model.NewFailedApp(appInfo, "bad date", true))
 			continue
 		}
 
@@ -141,18 +215,27 @@ func (s *Scheduler) checkGroup(groupID int64, isWeekend bool) {
 				Reviews: app.Reviews,
 				Ratings: app.Ratings,
 				IsApple: true,
+				Trend:   s.trend(model.StoreApple, appInfo),
 			})
 		}
 	}
 
 	// Check Google apps
 	for _, appInfo := range group.GoogleApps {
+		if appInfo.Muted {
+			continue
+		}
+
 		app, err := s.googleScraper.GetApp(appInfo.AppID, appInfo.Country)
+		s.trackAvailability(groupID, "Google", appInfo, appInfo.Country, err)
+		s.checkExtraStorefronts(groupID, "Google", appInfo)
 		if err != nil {
 			s.logger.Error("Failed to fetch Google app",
 				zap.Int64("groupId", groupID),
 				zap.String("appId", appInfo.AppID),
+				zap.String("errorKind", api.Label(err)),
 				zap.Error(err))
+			failedApps = append(failedApps, model.NewFailedApp(appInfo, api.Label(err), false))
 			continue
 		}
 
@@ -169,17 +252,18 @@ func (s *Scheduler) checkGroup(groupID int64, isWeekend bool) {
 				Reviews: app.Reviews,
 				Ratings: app.Ratings,
 				IsApple: false,
+				Trend:   s.trend(model.StoreGoogle, appInfo),
 			})
 		}
 	}
 
 	// Send report
-	if len(nonUpdatedApps) == 0 {
+	if len(nonUpdatedApps) == 0 && len(failedApps) == 0 {
 		s.logger.Info("No non-updated apps found for group", zap.Int64("groupId", groupID))
 		return
 	}
 
-	message := s.buildReport(groupID, nonUpdatedApps)
+	message := s.buildReport(groupID, nonUpdatedApps, failedApps)
 
 	var err2 error
 	if isWeekend {
@@ -196,11 +280,12 @@ func (s *Scheduler) checkGroup(groupID int64, isWeekend bool) {
 		s.logger.Info("Daily check report sent",
 			zap.Int64("groupId", groupID),
 			zap.Int("nonUpdatedApps", len(nonUpdatedApps)),
+			zap.Int("failedApps", len(failedApps)),
 			zap.Bool("silent", isWeekend))
 	}
 }
 
-func (s *Scheduler) buildReport(groupID int64, nonUpdatedApps []model.NonUpdatedApp) string {
+func (s *Scheduler) buildReport(groupID int64, nonUpdatedApps []model.NonUpdatedApp, failedApps []model.FailedApp) string {
 	var rows [][]string
 	for _, app := range nonUpdatedApps {
 		store := "Google"
@@ -216,17 +301,35 @@ func (s *Scheduler) buildReport(groupID int64, nonUpdatedApps []model.NonUpdated
 			fmt.Sprintf("%.1f", app.Score),
 			fmt.Sprintf("%v", app.Reviews),
 			util.FormatNumber(app.Ratings),
+			app.Trend.RatingsPerDay7.Format("%.1f"),
+			app.Trend.ReviewsPerDay7.Format("%.1f"),
+			app.Trend.ScoreDelta30.Format("%+.2f"),
 		})
 	}
 
-	headers := []string{"App", "Store", "Days", "Updated", "Score", "Reviews", "Ratings"}
+	headers := []string{"App", "Store", "Days", "Updated", "Score", "Reviews", "Ratings", "Rt/d 7d", "Rv/d 7d", "Delta30d"}
 	table := util.BuildTable(headers, rows)
 
 	now := time.Now().In(s.cfg.VietnamLocation)
-	return fmt.Sprintf("*Daily App Check Report*\nDate: %s\nGroup: %d\nApps not updated in >%d days: *%d*\n\n%s",
+	message := fmt.Sprintf("*Daily App Check Report*\nDate: %s\nGroup: %d\nApps not updated in >%d days: *%d*\n\n%s",
 		now.Format("2006-01-02 15:04"),
 		groupID,
 		s.cfg.NumDaysWarningNotUpdated,
 		len(nonUpdatedApps),
 		table)
+
+	return strings.TrimRight(message, "\n") + report.FailedSection(failedApps)
+}
+
+// trend computes the growth metrics of the app's primary storefront.
+func (s *Scheduler) trend(store string, appInfo model.AppInfo) model.Trend {
+	now := time.Now()
+	snapshots, err := s.snapshotRepo.GetHistory(store, appInfo.AppID, appInfo.Country, now.AddDate(0, 0, -metrics.TrendDays))
+	if err != nil {
+		s.logger.Error("Failed to get snapshots",
+			zap.String("appId", appInfo.AppID),
+			zap.Error(err))
+		return model.Trend{}
+	}
+	return metrics.Compute(snapshots, now)
 }
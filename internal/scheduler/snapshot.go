@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/miti99/store-scraper-bot-go/internal/api"
+	"github.com/miti99/store-scraper-bot-go/internal/model"
+	"go.uber.org/zap"
+)
+
+// runSnapshotCapture fetches every tracked storefront once a day. The
+// scrapers record a snapshot on each fetch, so this keeps the history
+// complete for muted apps and additional storefronts too.
+func (s *Scheduler) runSnapshotCapture() {
+	s.logger.Info("Running snapshot capture job")
+
+	groups, err := s.adminRepo.GetAllGroups()
+	if err != nil {
+		s.logger.Error("Failed to get groups for snapshot capture", zap.Error(err))
+		return
+	}
+
+	appleApps := make(map[string]bool)
+	googleApps := make(map[string]bool)
+	type storefront struct{ appID, country string }
+	var appleList, googleList []storefront
+
+	for _, groupID := range groups {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		group, err := s.groupRepo.Get(ctx, groupID)
+		cancel()
+		if err != nil {
+			s.logger.Error("Failed to get group", zap.Int64("groupId", groupID), zap.Error(err))
+			continue
+		}
+
+		for _, appInfo := range group.AppleApps {
+			for _, country := range appInfo.Storefronts() {
+				key := model.AppKey(appInfo.AppID, country)
+				if !appleApps[key] {
+					appleApps[key] = true
+					appleList = append(appleList, storefront{appInfo.AppID, country})
+				}
+			}
+		}
+		for _, appInfo := range group.GoogleApps {
+			for _, country := range appInfo.Storefronts() {
+				key := model.AppKey(appInfo.AppID, country)
+				if !googleApps[key] {
+					googleApps[key] = true
+					googleList = append(googleList, storefront{appInfo.AppID, country})
+				}
+			}
+		}
+	}
+
+	failed := 0
+	for _, sf := range appleList {
+		if _, err := s.appleScraper.GetApp(sf.appID, sf.country); err != nil {
+			failed++
+			s.logger.Warn("Failed to capture Apple app snapshot",
+				zap.String("appId", sf.appID),
+				zap.String("country", sf.country),
+				zap.String("errorKind", api.Label(err)))
+		}
+	}
+	for _, sf := range googleList {
+		if _, err := s.googleScraper.GetApp(sf.appID, sf.country); err != nil {
+			failed++
+			s.logger.Warn("Failed to capture Google app snapshot",
+				zap.String("appId", sf.appID),
+				zap.String("country", sf.country),
+				zap.String("errorKind", api.Label(err)))
+		}
+	}
+
+	s.logger.Info("Snapshot capture job completed",
+		zap.Int("storefronts", len(appleList)+len(googleList)),
+		zap.Int("failed", failed))
+}
@@ -0,0 +1,58 @@
+package util
+
+import "strings"
+
+// DiffLines returns a line diff of two texts with "- " for removed and "+ "
+// for added lines, leaving out unchanged lines. At most maxLines lines are
+// returned, followed by a note of how many were left out.
+func DiffLines(oldText, newText string, maxLines int) []string {
+	a := nonEmptyLines(oldText)
+	b := nonEmptyLines(newText)
+
+	// Longest common subsequence table
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var diff []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			diff = append(diff, "+ "+b[j])
+			j++
+		default:
+			diff = append(diff, "- "+a[i])
+			i++
+		}
+	}
+
+	if len(diff) > maxLines {
+		omitted := len(diff) - maxLines
+		diff = append(diff[:maxLines], "... "+FormatNumber(int64(omitted))+" more lines")
+	}
+	return diff
+}
+
+func nonEmptyLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,17 @@
+package util
+
+import "strings"
+
+var markdownEscaper = strings.NewReplacer("_", "\\_", "*", "\\*", "`", "\\`", "[", "\\[")
+
+// EscapeMarkdown escapes user supplied text, e.g. reviews, for Telegram's
+// legacy Markdown parse mode.
+func EscapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// Bold renders user supplied text in bold. Legacy Markdown cannot escape
+// within an entity, so asterisks that would end it early are dropped.
+func Bold(text string) string {
+	return "*" + strings.ReplaceAll(text, "*", "") + "*"
+}
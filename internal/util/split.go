@@ -0,0 +1,168 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// codeFence opens and closes Markdown code blocks.
+const codeFence = "```"
+
+// fenceReserve is kept free in every chunk to close an open code block and
+// reopen it with a language tag, e.g. "```json".
+const fenceReserve = 32
+
+// listEntryPattern matches the first line of a list entry, e.g. "3. " or "- ".
+var listEntryPattern = regexp.MustCompile(`^(\d+\.|[-•]) `)
+
+// messagePart is a line, or a piece of a long line, of a message.
+type messagePart struct {
+	text string
+	// fence is the opening line of the code block the part is in, if any.
+	fence string
+	// preferred marks a good place to start a chunk: after a blank line or
+	// at a list entry, outside of code blocks.
+	preferred bool
+}
+
+// SplitMessage splits text into chunks of at most limit bytes on line
+// boundaries, preferring blank lines and list entries in the second half of
+// a chunk, so paragraphs and entries stay whole. A code block cut between
+// chunks is closed at the end of one and reopened at the start of the next,
+// so each chunk renders on its own. Lines longer than a chunk are cut at a
+// space outside of Markdown spans where possible.
+func SplitMessage(text string, limit int) []string {
+	if len(text) <= limit {
+		return []string{text}
+	}
+
+	budget := limit - fenceReserve
+	parts := splitParts(text, budget)
+
+	// offsets[i] is the size of parts[:i] joined by newlines
+	offsets := make([]int, len(parts)+1)
+	for i, part := range parts {
+		offsets[i+1] = offsets[i] + len(part.text) + 1
+	}
+
+	var chunks []string
+	for start := 0; start < len(parts); {
+		reopen := 0
+		if parts[start].fence != "" {
+			reopen = len(parts[start].fence) + 1
+		}
+
+		end := start + 1
+		for end < len(parts) && reopen+offsets[end+1]-offsets[start] <= budget {
+			end++
+		}
+
+		if end < len(parts) {
+			for j := end; j > start+1; j-- {
+				if reopen+offsets[j]-offsets[start] < budget/2 {
+					break
+				}
+				if parts[j].preferred {
+					end = j
+					break
+				}
+			}
+		}
+
+		if chunk := renderChunk(parts[start:end], end < len(parts) && parts[end].fence != ""); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		start = end
+	}
+	return chunks
+}
+
+// splitParts cuts text into lines, and long lines into parts of at most
+// limit bytes, noting the code block and break preference of each.
+func splitParts(text string, limit int) []messagePart {
+	var parts []messagePart
+	fence := ""
+	prevBlank := false
+
+	for _, line := range strings.Split(text, "\n") {
+		for i, piece := range splitLine(line, limit) {
+			parts = append(parts, messagePart{
+				text:      piece,
+				fence:     fence,
+				preferred: i == 0 && fence == "" && (prevBlank || listEntryPattern.MatchString(piece)),
+			})
+
+			if strings.HasPrefix(strings.TrimSpace(piece), codeFence) {
+				if fence == "" {
+					fence = strings.TrimSpace(piece)
+				} else {
+					fence = ""
+				}
+			}
+		}
+		prevBlank = strings.TrimSpace(line) == ""
+	}
+	return parts
+}
+
+// renderChunk joins the parts, reopening the code block they start in and
+// closing the one they end in when it continues in the next chunk.
+func renderChunk(parts []messagePart, closeFence bool) string {
+	var sb strings.Builder
+	for _, part := range parts {
+		sb.WriteString(part.text + "\n")
+	}
+
+	chunk := strings.Trim(sb.String(), "\n")
+	if parts[0].fence != "" {
+		chunk = parts[0].fence + "\n" + chunk
+	}
+	if closeFence {
+		chunk += "\n" + codeFence
+	}
+	return chunk
+}
+
+// splitLine cuts a line into parts of at most limit bytes, at the last space
+// outside of Markdown spans in the second half of a part if there is one.
+func splitLine(line string, limit int) []string {
+	var parts []string
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		for i := cut; i > limit/2; i-- {
+			if line[i] == ' ' && spansClosed(line[:i]) {
+				cut = i
+				break
+			}
+		}
+
+		parts = append(parts, line[:cut])
+		line = strings.TrimPrefix(line[cut:], " ")
+	}
+	return append(parts, line)
+}
+
+// spansClosed reports whether every legacy Markdown span opened in text is
+// closed again, ignoring escaped characters.
+func spansClosed(text string) bool {
+	open := byte(0)
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '\\' && open == 0:
+			i++
+		case open != 0:
+			if c == open {
+				open = 0
+			}
+		case c == '*' || c == '_' || c == '`':
+			open = c
+		}
+	}
+	return open == 0
+}
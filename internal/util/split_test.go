@@ -0,0 +1,81 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSplitMessageShortText(t *testing.T) {
+	chunks := SplitMessage("*Title*\nline", 100)
+	if len(chunks) != 1 || chunks[0] != "*Title*\nline" {
+		t.Errorf("SplitMessage = %q, want the text unchanged", chunks)
+	}
+}
+
+func TestSplitMessageKeepsParagraphs(t *testing.T) {
+	var paragraphs []string
+	for i := 0; i < 20; i++ {
+		paragraphs = append(paragraphs, fmt.Sprintf("*Review %d*\nfirst line\nsecond line", i))
+	}
+	text := strings.Join(paragraphs, "\n\n")
+
+	chunks := SplitMessage(text, 200)
+	if len(chunks) < 2 {
+		t.Fatalf("got %d chunks, want the text split", len(chunks))
+	}
+	for _, chunk := range chunks {
+		if len(chunk) > 200 {
+			t.Errorf("chunk of %d bytes exceeds the limit", len(chunk))
+		}
+		if !strings.HasPrefix(chunk, "*Review ") || !strings.HasSuffix(chunk, "second line") {
+			t.Errorf("chunk does not hold whole paragraphs: %q", chunk)
+		}
+	}
+}
+
+func TestSplitMessageKeepsListEntries(t *testing.T) {
+	var entries []string
+	for i := 1; i <= 30; i++ {
+		entries = append(entries, fmt.Sprintf("%d. com.example.app%d\n   details", i, i))
+	}
+	text := "*Apps:*\n" + strings.Join(entries, "\n")
+
+	for _, chunk := range SplitMessage(text, 150)[1:] {
+		if !listEntryPattern.MatchString(chunk) {
+			t.Errorf("chunk does not start at a list entry: %q", chunk)
+		}
+	}
+}
+
+func TestSplitMessageReopensCodeBlock(t *testing.T) {
+	rows := make([]string, 40)
+	for i := range rows {
+		rows[i] = fmt.Sprintf("row %02d | value", i)
+	}
+	text := "*Table*\n```\n" + strings.Join(rows, "\n") + "\n```"
+
+	chunks := SplitMessage(text, 200)
+	for i, chunk := range chunks {
+		if strings.Count(chunk, codeFence)%2 != 0 {
+			t.Errorf("chunk %d has an unclosed code block: %q", i, chunk)
+		}
+	}
+}
+
+func TestSplitLineKeepsSpans(t *testing.T) {
+	line := strings.Repeat("word ", 10) + "*bold span here*" + strings.Repeat(" word", 10)
+
+	parts := splitLine(line, 60)
+	if len(parts) < 2 {
+		t.Fatalf("got %d parts, want the line split", len(parts))
+	}
+	for _, part := range parts {
+		if len(part) > 60 {
+			t.Errorf("part of %d bytes exceeds the limit", len(part))
+		}
+		if !spansClosed(part) {
+			t.Errorf("part cuts a Markdown span: %q", part)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package util
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 func BuildTable(headers []string, rows [][]string) string {
@@ -78,7 +79,13 @@ func TruncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
 	}
-	return s[:maxLen-3] + "..."
+
+	// Cut on a rune boundary so multi-byte text stays valid UTF-8
+	cut := maxLen - 3
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
 }
 
 func FormatNumber(n int64) string {
@@ -0,0 +1,48 @@
+package util
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CompareVersions compares dotted version strings numerically, e.g.
+// "1.10.0" > "1.9.2". Non-numeric parts are compared as strings. It returns
+// -1, 0 or 1.
+func CompareVersions(a, b string) int {
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var pa, pb string
+		if i < len(partsA) {
+			pa = partsA[i]
+		}
+		if i < len(partsB) {
+			pb = partsB[i]
+		}
+
+		na, errA := strconv.Atoi(pa)
+		nb, errB := strconv.Atoi(pb)
+		if pa == "" {
+			na, errA = 0, nil
+		}
+		if pb == "" {
+			nb, errB = 0, nil
+		}
+
+		if errA == nil && errB == nil {
+			if na != nb {
+				if na < nb {
+					return -1
+				}
+				return 1
+			}
+			continue
+		}
+
+		if c := strings.Compare(pa, pb); c != 0 {
+			return c
+		}
+	}
+	return 0
+}